@@ -3,10 +3,12 @@ package authenticate // import "github.com/pomerium/pomerium/authenticate"
 import (
 	"crypto/cipher"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
 	"net/url"
+	"time"
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/cryptutil"
@@ -21,6 +23,11 @@ import (
 
 const callbackPath = "/oauth2/callback"
 
+// revokedSessionsTTL is how long revoked sessions are remembered, and
+// rejected, for. Sessions are refreshed, and so can be used, for as long as
+// the identity provider allows.
+const revokedSessionsTTL = 30 * 24 * time.Hour
+
 // ValidateOptions checks that configuration are complete and valid.
 // Returns on first error found.
 func ValidateOptions(o config.Options) error {
@@ -39,6 +46,9 @@ func ValidateOptions(o config.Options) error {
 	if o.ClientSecret == "" {
 		return errors.New("authenticate: 'IDP_CLIENT_SECRET' is required")
 	}
+	if _, err := jws.PublicJWKSet(o.SigningKey); err != nil {
+		return fmt.Errorf("authenticate: invalid 'SIGNING_KEY': %w", err)
+	}
 	return nil
 }
 
@@ -56,6 +66,9 @@ type Authenticate struct {
 	// sharedEncoder is the encoder to use to serialize data to be consumed
 	// by other services
 	sharedEncoder encoding.MarshalUnmarshaler
+	// sharedEncryptedEncoder is the encoder to use to serialize a user's
+	// identity provider tokens for the proxy service
+	sharedEncryptedEncoder encoding.MarshalUnmarshaler
 
 	// data related to this service only
 	cookieOptions *sessions.CookieOptions
@@ -67,11 +80,19 @@ type Authenticate struct {
 	encryptedEncoder encoding.MarshalUnmarshaler
 	sessionStores    []sessions.SessionStore
 	sessionLoaders   []sessions.SessionLoader
+	// inventory tracks users' sessions, so that they can be listed and
+	// revoked
+	inventory sessions.Inventory
+	// deviceAuthorizations are the pending device authorization requests
+	deviceAuthorizations *deviceAuthorizations
 
 	// provider is the interface to interacting with the identity provider (IdP)
 	provider identity.Authenticator
 
 	templates *template.Template
+	// jwks is the json encoded public key set of the key signing the
+	// proxy's identity headers
+	jwks []byte
 }
 
 // New validates and creates a new authenticate service from a set of Options.
@@ -107,6 +128,20 @@ func New(opts config.Options) (*Authenticate, error) {
 	qpStore := sessions.NewQueryParamStore(encryptedEncoder, "pomerium_programmatic_token")
 	headerStore := sessions.NewHeaderStore(encryptedEncoder, "Pomerium")
 
+	// revoked sessions are rejected by all session loaders
+	inventory := sessions.NewMemoryInventory(revokedSessionsTTL)
+	var sessionLoaders []sessions.SessionLoader
+	for _, l := range []sessions.SessionLoader{qpStore, headerStore, cookieStore} {
+		sessionLoaders = append(sessionLoaders, sessions.NewRevocationLoader(l, inventory))
+	}
+
+	// errors checked in ValidateOptions
+	jwks, _ := jws.PublicJWKSet(opts.SigningKey)
+	encodedJWKS, err := json.Marshal(jwks)
+	if err != nil {
+		return nil, err
+	}
+
 	redirectURL, _ := urlutil.DeepCopy(opts.AuthenticateURL)
 	redirectURL.Path = callbackPath
 	// configure our identity provider
@@ -131,17 +166,24 @@ func New(opts config.Options) (*Authenticate, error) {
 		sharedKey:     opts.SharedKey,
 		sharedCipher:  sharedCipher,
 		sharedEncoder: signedEncoder,
+		// identity provider tokens are encrypted, not only signed, as they
+		// are credentials in their own right
+		sharedEncryptedEncoder: ecjson.New(sharedCipher),
 		// private state
 		cookieSecret:     decodedCookieSecret,
 		cookieCipher:     cookieCipher,
 		cookieOptions:    cookieOptions,
 		sessionStore:     cookieStore,
 		encryptedEncoder: encryptedEncoder,
-		sessionLoaders:   []sessions.SessionLoader{qpStore, headerStore, cookieStore},
+		sessionLoaders:   sessionLoaders,
 		sessionStores:    []sessions.SessionStore{cookieStore, qpStore},
+		inventory:        inventory,
+
+		deviceAuthorizations: newDeviceAuthorizations(),
 		// IdP
 		provider: provider,
 
 		templates: template.Must(frontend.NewTemplates()),
+		jwks:      encodedJWKS,
 	}, nil
 }
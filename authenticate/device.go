@@ -0,0 +1,454 @@
+package authenticate // import "github.com/pomerium/pomerium/authenticate"
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/csrf"
+	"github.com/pomerium/pomerium/internal/cryptutil"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+const (
+	// deviceURL is the path to the page users approve devices on
+	deviceURL = "/device"
+	// deviceCodeURL is the path to the device authorization endpoint
+	deviceCodeURL = "/api/v1/device/code"
+	// deviceTokenURL is the path to the device access token endpoint
+	deviceTokenURL = "/api/v1/device/token"
+
+	// deviceGrantType is the grant type of device access token requests
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+	// deviceCodeTTL is how long users have to approve a device
+	deviceCodeTTL = 10 * time.Minute
+	// devicePollInterval is how often devices may poll for an access token
+	devicePollInterval = 5 * time.Second
+
+	// userCodeCharset are the characters of user codes; consonants only, so
+	// that codes are easy to type and do not spell words
+	userCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+	// userCodeLength is the number of characters in a user code
+	userCodeLength = 8
+
+	// maxDeviceAuthorizations is the number of pending device authorizations
+	// there can be at once
+	maxDeviceAuthorizations = 10000
+	// deviceCodeRateLimit is the number of device authorizations that can be
+	// started from an ip address each deviceRateLimitWindow
+	deviceCodeRateLimit = 10
+	// userCodeRateLimit is the number of user codes a user can enter each
+	// deviceRateLimitWindow, so that user codes cannot be guessed
+	userCodeRateLimit = 10
+	// deviceRateLimitWindow is the window device rate limits are counted in
+	deviceRateLimitWindow = time.Minute
+)
+
+var (
+	errTooManyDeviceRequests       = errors.New("authenticate: too many device authorization requests")
+	errTooManyDeviceAuthorizations = errors.New("authenticate: too many pending device authorizations")
+)
+
+// Device access token error codes.
+//
+// https://tools.ietf.org/html/rfc8628#section-3.5
+const (
+	deviceAuthorizationPending = "authorization_pending"
+	deviceSlowDown             = "slow_down"
+	deviceAccessDenied         = "access_denied"
+	deviceExpiredToken         = "expired_token"
+	deviceInvalidGrant         = "invalid_grant"
+	deviceInvalidRequest       = "invalid_request"
+	deviceUnsupportedGrantType = "unsupported_grant_type"
+)
+
+// deviceAuthorization is a device's pending request for a programmatic
+// session.
+type deviceAuthorization struct {
+	deviceCode string
+	userCode   string
+	// audience are the hostnames of the routes the session is for
+	audience  []string
+	userAgent string
+	ipAddress string
+	expires   time.Time
+	interval  time.Duration
+	lastPoll  time.Time
+
+	// session is the approving user's session; nil until approved
+	session *sessions.State
+	denied  bool
+}
+
+// deviceAuthorizations are the pending device authorizations, by device and
+// user code.
+type deviceAuthorizations struct {
+	mu           sync.Mutex
+	byDeviceCode map[string]*deviceAuthorization
+	byUserCode   map[string]*deviceAuthorization
+
+	// deviceCodeLimiter limits new authorizations by ip address
+	deviceCodeLimiter *rateLimiter
+	// userCodeLimiter limits the user codes entered by each user
+	userCodeLimiter *rateLimiter
+}
+
+func newDeviceAuthorizations() *deviceAuthorizations {
+	return &deviceAuthorizations{
+		byDeviceCode:      make(map[string]*deviceAuthorization),
+		byUserCode:        make(map[string]*deviceAuthorization),
+		deviceCodeLimiter: newRateLimiter(deviceCodeRateLimit, deviceRateLimitWindow),
+		userCodeLimiter:   newRateLimiter(userCodeRateLimit, deviceRateLimitWindow),
+	}
+}
+
+// add adds a new pending authorization, with new device and user codes. The
+// device code endpoint is unauthenticated, so authorizations are limited by
+// ip address, and in number.
+func (da *deviceAuthorizations) add(audience []string, r *http.Request) (*deviceAuthorization, error) {
+	ipAddress := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+		ipAddress = host
+	}
+	if !da.deviceCodeLimiter.allow(ipAddress) {
+		return nil, errTooManyDeviceRequests
+	}
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	da.prune(time.Now())
+	if len(da.byDeviceCode) >= maxDeviceAuthorizations {
+		return nil, errTooManyDeviceAuthorizations
+	}
+	auth := &deviceAuthorization{
+		deviceCode: base64.RawURLEncoding.EncodeToString(cryptutil.NewKey()),
+		audience:   audience,
+		userAgent:  r.UserAgent(),
+		ipAddress:  ipAddress,
+		expires:    time.Now().Add(deviceCodeTTL),
+		interval:   devicePollInterval,
+	}
+	for auth.userCode == "" || da.byUserCode[auth.userCode] != nil {
+		auth.userCode = newUserCode()
+	}
+	da.byDeviceCode[auth.deviceCode] = auth
+	da.byUserCode[auth.userCode] = auth
+	return auth, nil
+}
+
+// allowUserCode reports whether the user may enter another user code.
+func (da *deviceAuthorizations) allowUserCode(user string) bool {
+	return da.userCodeLimiter.allow(user)
+}
+
+// lookup returns a copy of the pending authorization with the given user
+// code, if any.
+func (da *deviceAuthorizations) lookup(userCode string) (deviceAuthorization, bool) {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	auth, ok := da.byUserCode[normalizeUserCode(userCode)]
+	if !ok || time.Now().After(auth.expires) || auth.session != nil || auth.denied {
+		return deviceAuthorization{}, false
+	}
+	return *auth, true
+}
+
+// approve approves, or denies if session is nil, the pending authorization
+// with the given user code.
+func (da *deviceAuthorizations) approve(userCode string, session *sessions.State) bool {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	auth, ok := da.byUserCode[normalizeUserCode(userCode)]
+	if !ok || time.Now().After(auth.expires) || auth.session != nil || auth.denied {
+		return false
+	}
+	delete(da.byUserCode, auth.userCode)
+	auth.session = session
+	auth.denied = session == nil
+	return true
+}
+
+// poll returns the approving user's session, once the authorization with the
+// given device code has been approved, or else the error code to respond to
+// the device with. Approved authorizations can only be redeemed once.
+func (da *deviceAuthorizations) poll(deviceCode string) (*deviceAuthorization, string) {
+	da.mu.Lock()
+	defer da.mu.Unlock()
+	auth, ok := da.byDeviceCode[deviceCode]
+	now := time.Now()
+	switch {
+	case !ok:
+		return nil, deviceInvalidGrant
+	case now.After(auth.expires):
+		da.remove(auth)
+		return nil, deviceExpiredToken
+	case auth.denied:
+		da.remove(auth)
+		return nil, deviceAccessDenied
+	case auth.session != nil:
+		da.remove(auth)
+		return auth, ""
+	case now.Sub(auth.lastPoll) < auth.interval:
+		// https://tools.ietf.org/html/rfc8628#section-3.5
+		auth.interval += devicePollInterval
+		auth.lastPoll = now
+		return nil, deviceSlowDown
+	default:
+		auth.lastPoll = now
+		return nil, deviceAuthorizationPending
+	}
+}
+
+// prune removes expired authorizations. mu must be held.
+func (da *deviceAuthorizations) prune(now time.Time) {
+	for _, auth := range da.byDeviceCode {
+		if now.After(auth.expires) {
+			da.remove(auth)
+		}
+	}
+}
+
+// remove removes the authorization. mu must be held.
+func (da *deviceAuthorizations) remove(auth *deviceAuthorization) {
+	delete(da.byDeviceCode, auth.deviceCode)
+	if da.byUserCode[auth.userCode] == auth {
+		delete(da.byUserCode, auth.userCode)
+	}
+}
+
+// newUserCode returns a random user code.
+func newUserCode() string {
+	// random bytes past the largest multiple of the charset's length are
+	// rejected, so that each character is as likely
+	max := 256 - 256%len(userCodeCharset)
+	code := make([]byte, 0, userCodeLength)
+	b := make([]byte, userCodeLength)
+	for len(code) < userCodeLength {
+		if _, err := rand.Read(b); err != nil {
+			panic(err)
+		}
+		for _, c := range b {
+			if int(c) < max && len(code) < userCodeLength {
+				code = append(code, userCodeCharset[int(c)%len(userCodeCharset)])
+			}
+		}
+	}
+	return string(code)
+}
+
+// rateLimiter allows up to limit events for each key in each window.
+type rateLimiter struct {
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	start  time.Time
+	counts map[string]int
+}
+
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{limit: limit, window: window, counts: make(map[string]int)}
+}
+
+// allow reports whether another event is allowed for key.
+func (rl *rateLimiter) allow(key string) bool {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if now := time.Now(); now.Sub(rl.start) >= rl.window {
+		rl.start = now
+		rl.counts = make(map[string]int)
+	}
+	rl.counts[key]++
+	return rl.counts[key] <= rl.limit
+}
+
+// normalizeUserCode uppercases a user code as typed by the user, removing
+// any separators.
+func normalizeUserCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(code))
+}
+
+// formatUserCode formats a user code, for display, as `XXXX-XXXX`.
+func formatUserCode(code string) string {
+	return code[:userCodeLength/2] + "-" + code[userCodeLength/2:]
+}
+
+// DeviceCodeAPI starts the device authorization flow for command line tools,
+// and other devices that cannot receive a callback, by returning a device
+// code, a user code, and the url the user can approve the device at. The
+// `resource` form values are the urls of the routes the device wants a
+// session for.
+//
+// https://tools.ietf.org/html/rfc8628#section-3.1
+func (a *Authenticate) DeviceCodeAPI(w http.ResponseWriter, r *http.Request) error {
+	if err := r.ParseForm(); err != nil {
+		return writeDeviceError(w, deviceInvalidRequest, err.Error())
+	}
+	resources := r.PostForm["resource"]
+	if len(resources) == 0 {
+		return writeDeviceError(w, deviceInvalidRequest, "resource is required")
+	}
+	audience := []string{a.RedirectURL.Hostname()}
+	for _, resource := range resources {
+		u, err := urlutil.ParseAndValidateURL(resource)
+		if err != nil {
+			return writeDeviceError(w, deviceInvalidRequest, err.Error())
+		}
+		audience = append(audience, u.Hostname())
+	}
+	auth, err := a.deviceAuthorizations.add(audience, r)
+	if err == errTooManyDeviceRequests {
+		return httputil.NewError(http.StatusTooManyRequests, err)
+	} else if err != nil {
+		return httputil.NewError(http.StatusServiceUnavailable, err)
+	}
+
+	verificationURL := a.RedirectURL.ResolveReference(&url.URL{Path: deviceURL})
+	verificationURLComplete := *verificationURL
+	verificationURLComplete.RawQuery = url.Values{"user_code": {formatUserCode(auth.userCode)}}.Encode()
+	response := struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}{
+		DeviceCode:              auth.deviceCode,
+		UserCode:                formatUserCode(auth.userCode),
+		VerificationURI:         verificationURL.String(),
+		VerificationURIComplete: verificationURLComplete.String(),
+		ExpiresIn:               int(deviceCodeTTL.Seconds()),
+		Interval:                int(devicePollInterval.Seconds()),
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	return writeJSON(w, &response)
+}
+
+// DeviceTokenAPI is polled by devices for the programmatic session of the
+// user that approved them. Once approved, the device receives a new
+// session's JWT and refresh token, as returned by RefreshAPI.
+//
+// https://tools.ietf.org/html/rfc8628#section-3.4
+func (a *Authenticate) DeviceTokenAPI(w http.ResponseWriter, r *http.Request) error {
+	if r.PostFormValue("grant_type") != deviceGrantType {
+		return writeDeviceError(w, deviceUnsupportedGrantType, "")
+	}
+	auth, code := a.deviceAuthorizations.poll(r.PostFormValue("device_code"))
+	if code != "" {
+		return writeDeviceError(w, code, "")
+	}
+
+	// the device gets a session of its own, so that it can be revoked
+	// separately from the browser session that approved it
+	newSession := auth.session.NewSession(a.RedirectURL.Host, auth.audience)
+	newSession.Programmatic = true
+	newSession.ID = cryptutil.NewRandomStringN(32)
+	record := sessions.NewRecord(r, newSession, time.Now().Add(a.cookieOptions.Expire))
+	record.UserAgent = auth.userAgent
+	record.IPAddress = auth.ipAddress
+	if err := a.inventory.Add(r.Context(), record); err != nil {
+		return err
+	}
+
+	encSession, err := a.encryptedEncoder.Marshal(newSession)
+	if err != nil {
+		return err
+	}
+	signedJWT, err := a.sharedEncoder.Marshal(newSession.RouteSession())
+	if err != nil {
+		return err
+	}
+	var response struct {
+		JWT          string `json:"jwt"`
+		RefreshToken string `json:"refresh_token"`
+		// the standard oauth2 token response fields
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in,omitempty"`
+	}
+	response.JWT = string(signedJWT)
+	response.RefreshToken = string(encSession)
+	response.AccessToken = response.JWT
+	response.TokenType = "Pomerium"
+	if newSession.Expiry != nil {
+		response.ExpiresIn = int64(time.Until(newSession.Expiry.Time()).Seconds())
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	return writeJSON(w, &response)
+}
+
+// writeDeviceError writes an oauth2 error response, as polling devices
+// expect.
+//
+// https://tools.ietf.org/html/rfc6749#section-5.2
+func writeDeviceError(w http.ResponseWriter, code, description string) error {
+	response := struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description,omitempty"`
+	}{code, description}
+	jsonResponse, err := json.Marshal(&response)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusBadRequest)
+	w.Write(jsonResponse)
+	return nil
+}
+
+// Device lets signed in users approve, or deny, a device's request for a
+// programmatic session, given the user code shown on the device.
+func (a *Authenticate) Device(w http.ResponseWriter, r *http.Request) error {
+	s, err := sessions.FromContext(r.Context())
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	userCode := r.FormValue("user_code")
+	if userCode != "" && !a.deviceAuthorizations.allowUserCode(s.Subject) {
+		return httputil.NewError(http.StatusTooManyRequests, errors.New("authenticate: too many device codes entered"))
+	}
+	data := map[string]interface{}{
+		"Session":   s,
+		"UserCode":  userCode,
+		"csrfField": csrf.TemplateField(r),
+	}
+	switch {
+	case r.Method == http.MethodPost:
+		var approval *sessions.State
+		if r.FormValue("action") == "approve" {
+			approval = s
+		}
+		if !a.deviceAuthorizations.approve(userCode, approval) {
+			return httputil.NewError(http.StatusBadRequest, errors.New("authenticate: invalid or expired device code"))
+		}
+		log.FromRequest(r).Info().Str("email", s.Email).Bool("approved", approval != nil).Msg("authenticate: device authorization")
+		data["Approved"] = approval != nil
+		data["Done"] = true
+	case userCode != "":
+		auth, ok := a.deviceAuthorizations.lookup(userCode)
+		if !ok {
+			return httputil.NewError(http.StatusBadRequest, errors.New("authenticate: invalid or expired device code"))
+		}
+		data["UserCode"] = formatUserCode(auth.userCode)
+		data["Routes"] = auth.audience[1:]
+		data["UserAgent"] = auth.userAgent
+		data["IPAddress"] = auth.ipAddress
+	}
+	return a.templates.ExecuteTemplate(w, "device.html", data)
+}
@@ -0,0 +1,287 @@
+package authenticate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pomerium/pomerium/internal/encoding/mock"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/sessions"
+)
+
+func TestDeviceAuthorizations(t *testing.T) {
+	t.Parallel()
+	da := newDeviceAuthorizations()
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	r.Header.Set("User-Agent", "cli")
+	session := &sessions.State{Subject: "user"}
+
+	pending, err := da.add([]string{"auth.example.com"}, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending.userCode) != userCodeLength || pending.ipAddress != "10.0.0.1" || pending.userAgent != "cli" {
+		t.Errorf("add() = %+v", pending)
+	}
+	if _, code := da.poll(pending.deviceCode); code != deviceAuthorizationPending {
+		t.Errorf("poll() = %q, want %q", code, deviceAuthorizationPending)
+	}
+	if _, code := da.poll(pending.deviceCode); code != deviceSlowDown {
+		t.Errorf("poll() too soon = %q, want %q", code, deviceSlowDown)
+	}
+	if pending.interval != 2*devicePollInterval {
+		t.Errorf("interval = %v, want %v", pending.interval, 2*devicePollInterval)
+	}
+	if _, ok := da.lookup(strings.ToLower(formatUserCode(pending.userCode))); !ok {
+		t.Error("lookup() of formatted user code failed")
+	}
+	if !da.approve(pending.userCode, session) {
+		t.Fatal("approve() failed")
+	}
+	if da.approve(pending.userCode, session) {
+		t.Error("approved twice")
+	}
+	if _, ok := da.lookup(pending.userCode); ok {
+		t.Error("lookup() of approved user code succeeded")
+	}
+	if auth, code := da.poll(pending.deviceCode); code != "" || auth.session != session {
+		t.Errorf("poll() approved = %v, %q", auth, code)
+	}
+	// approvals can only be redeemed once
+	if _, code := da.poll(pending.deviceCode); code != deviceInvalidGrant {
+		t.Errorf("poll() redeemed = %q, want %q", code, deviceInvalidGrant)
+	}
+
+	denied, _ := da.add(nil, r)
+	da.approve(denied.userCode, nil)
+	if _, code := da.poll(denied.deviceCode); code != deviceAccessDenied {
+		t.Errorf("poll() denied = %q, want %q", code, deviceAccessDenied)
+	}
+
+	expired, _ := da.add(nil, r)
+	expired.expires = time.Now().Add(-time.Second)
+	if da.approve(expired.userCode, session) {
+		t.Error("approved expired code")
+	}
+	if _, code := da.poll(expired.deviceCode); code != deviceExpiredToken {
+		t.Errorf("poll() expired = %q, want %q", code, deviceExpiredToken)
+	}
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		code string
+		want string
+	}{
+		{"BCDF-GHJK", "BCDFGHJK"},
+		{"bcdf ghjk", "BCDFGHJK"},
+		{"BCDFGHJK", "BCDFGHJK"},
+	}
+	for _, tt := range tests {
+		if got := normalizeUserCode(tt.code); got != tt.want {
+			t.Errorf("normalizeUserCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+	for i := 0; i < 100; i++ {
+		if code := newUserCode(); strings.Trim(code, userCodeCharset) != "" {
+			t.Fatalf("newUserCode() = %q", code)
+		}
+	}
+}
+
+func TestNewUserCode_uniform(t *testing.T) {
+	t.Parallel()
+	const n = 20000
+	counts := make(map[rune]int)
+	for i := 0; i < n; i++ {
+		for _, c := range newUserCode() {
+			counts[c]++
+		}
+	}
+	// a biased code would pick some characters 8% less often than others
+	want := n * userCodeLength / len(userCodeCharset)
+	for _, c := range userCodeCharset {
+		if got := counts[c]; got < want*95/100 || got > want*105/100 {
+			t.Errorf("%c picked %d times, want about %d", c, got, want)
+		}
+	}
+}
+
+func TestDeviceAuthorizations_limits(t *testing.T) {
+	t.Parallel()
+	da := newDeviceAuthorizations()
+	r := httptest.NewRequest(http.MethodPost, "/", nil)
+	r.RemoteAddr = "10.0.0.1:1234"
+	for i := 0; i < deviceCodeRateLimit; i++ {
+		if _, err := da.add(nil, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := da.add(nil, r); err != errTooManyDeviceRequests {
+		t.Errorf("add() over the rate limit error = %v, want %v", err, errTooManyDeviceRequests)
+	}
+	other := httptest.NewRequest(http.MethodPost, "/", nil)
+	other.RemoteAddr = "10.0.0.2:1234"
+	if _, err := da.add(nil, other); err != nil {
+		t.Errorf("add() from another address error = %v", err)
+	}
+
+	// the number of pending authorizations is capped, whatever their source
+	da = newDeviceAuthorizations()
+	da.deviceCodeLimiter = newRateLimiter(maxDeviceAuthorizations+1, time.Minute)
+	for i := 0; i < maxDeviceAuthorizations; i++ {
+		if _, err := da.add(nil, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := da.add(nil, r); err != errTooManyDeviceAuthorizations {
+		t.Errorf("add() over the cap error = %v, want %v", err, errTooManyDeviceAuthorizations)
+	}
+
+	for i := 0; i < userCodeRateLimit; i++ {
+		if !da.allowUserCode("user") {
+			t.Fatal("allowUserCode() = false")
+		}
+	}
+	if da.allowUserCode("user") {
+		t.Error("allowUserCode() over the rate limit = true")
+	}
+	if !da.allowUserCode("other") {
+		t.Error("allowUserCode() for another user = false")
+	}
+}
+
+func TestRateLimiter(t *testing.T) {
+	t.Parallel()
+	rl := newRateLimiter(2, time.Hour)
+	for i, want := range []bool{true, true, false} {
+		if got := rl.allow("a"); got != want {
+			t.Errorf("allow() %d = %v, want %v", i, got, want)
+		}
+	}
+	if !rl.allow("b") {
+		t.Error("allow() other key = false")
+	}
+	// the counts are reset each window
+	rl.start = time.Now().Add(-time.Hour)
+	if !rl.allow("a") {
+		t.Error("allow() in a new window = false")
+	}
+}
+
+func TestAuthenticate_DeviceFlow(t *testing.T) {
+	t.Parallel()
+	a := testAuthenticate()
+	a.inventory = sessions.NewMemoryInventory(time.Hour)
+	a.deviceAuthorizations = newDeviceAuthorizations()
+	a.cookieOptions.Expire = time.Hour
+	a.encryptedEncoder = mock.Encoder{MarshalResponse: []byte("refresh")}
+	a.sharedEncoder = mock.Encoder{MarshalResponse: []byte("jwt")}
+
+	post := func(path string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(http.MethodPost, "https://auth.example.com"+path, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		r.Header.Set("User-Agent", "pomerium-cli")
+		w := httptest.NewRecorder()
+		a.Handler().ServeHTTP(w, r)
+		return w
+	}
+
+	if w := post(deviceCodeURL, nil); w.Code != http.StatusBadRequest {
+		t.Errorf("device code without resource status = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	w := post(deviceCodeURL, url.Values{"resource": {"https://httpbin.corp.example"}})
+	if w.Code != http.StatusOK {
+		t.Fatalf("device code status = %v, want %v\n%v", w.Code, http.StatusOK, w.Body.String())
+	}
+	var code struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &code); err != nil {
+		t.Fatal(err)
+	}
+	if code.VerificationURI != "https://auth.example.com/device" ||
+		code.VerificationURIComplete != "https://auth.example.com/device?user_code="+code.UserCode ||
+		code.ExpiresIn != 600 || code.Interval != 5 {
+		t.Errorf("device code = %+v", code)
+	}
+
+	token := func(grantType string) (int, string) {
+		t.Helper()
+		w := post(deviceTokenURL, url.Values{"grant_type": {grantType}, "device_code": {code.DeviceCode}})
+		return w.Code, w.Body.String()
+	}
+	if status, body := token("refresh_token"); status != http.StatusBadRequest || !strings.Contains(body, deviceUnsupportedGrantType) {
+		t.Errorf("token with wrong grant type = %v %s", status, body)
+	}
+	if status, body := token(deviceGrantType); status != http.StatusBadRequest || !strings.Contains(body, deviceAuthorizationPending) {
+		t.Errorf("token before approval = %v %s", status, body)
+	}
+
+	// the signed in user reviews, then approves, the device
+	session := &sessions.State{ID: "browser", Subject: "user", Email: "user@example.com"}
+	device := func(method string, form url.Values) *httptest.ResponseRecorder {
+		t.Helper()
+		r := httptest.NewRequest(method, "https://auth.example.com/device?"+form.Encode(), nil)
+		r = r.WithContext(sessions.NewContext(r.Context(), session, nil))
+		w := httptest.NewRecorder()
+		httputil.HandlerFunc(a.Device).ServeHTTP(w, r)
+		return w
+	}
+	w = device(http.MethodGet, url.Values{"user_code": {code.UserCode}})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "httpbin.corp.example") {
+		t.Errorf("device page = %v\n%v", w.Code, w.Body.String())
+	}
+	if w := device(http.MethodGet, url.Values{"user_code": {"BBBB-BBBB"}}); w.Code != http.StatusBadRequest {
+		t.Errorf("device page with unknown code = %v, want %v", w.Code, http.StatusBadRequest)
+	}
+	w = device(http.MethodPost, url.Values{"user_code": {code.UserCode}, "action": {"approve"}})
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "signed in as") {
+		t.Errorf("device approval = %v\n%v", w.Code, w.Body.String())
+	}
+
+	status, body := token(deviceGrantType)
+	if status != http.StatusOK {
+		t.Fatalf("token status = %v, want %v\n%v", status, http.StatusOK, body)
+	}
+	var tokens map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &tokens); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"jwt": "jwt", "refresh_token": "refresh", "access_token": "jwt", "token_type": "Pomerium"}
+	if diff := cmp.Diff(want, tokens); diff != "" {
+		t.Errorf("token response = %s", diff)
+	}
+
+	// the device's session is tracked separately from the browser's
+	records, _ := a.inventory.List(context.Background(), "user")
+	if len(records) != 1 || records[0].ID == "" || records[0].ID == session.ID || records[0].UserAgent != "pomerium-cli" {
+		t.Errorf("tracked sessions = %+v", records)
+	}
+	if status, body := token(deviceGrantType); status != http.StatusBadRequest || !strings.Contains(body, deviceInvalidGrant) {
+		t.Errorf("token redeemed twice = %v %s", status, body)
+	}
+
+	// users can only enter so many user codes, so that codes cannot be guessed
+	for i := 0; i < userCodeRateLimit; i++ {
+		status = device(http.MethodGet, url.Values{"user_code": {"BBBB-BBBB"}}).Code
+	}
+	if status != http.StatusTooManyRequests {
+		t.Errorf("device page over the rate limit = %v, want %v", status, http.StatusTooManyRequests)
+	}
+}
@@ -25,6 +25,7 @@ import (
 func (a *Authenticate) Handler() http.Handler {
 	r := httputil.NewRouter()
 	r.Use(middleware.SetHeaders(httputil.HeadersContentSecurityPolicy))
+	r.Use(a.skipCSRFForSignedAPIRequests)
 	r.Use(csrf.Protect(
 		a.cookieSecret,
 		csrf.Secure(a.cookieOptions.Secure),
@@ -36,8 +37,11 @@ func (a *Authenticate) Handler() http.Handler {
 	))
 
 	r.Path("/robots.txt").HandlerFunc(a.RobotsTxt).Methods(http.MethodGet)
+	r.Path("/.well-known/pomerium/jwks.json").HandlerFunc(a.JWKS).Methods(http.MethodGet)
 	// Identity Provider (IdP) endpoints
 	r.Path("/oauth2/callback").Handler(httputil.HandlerFunc(a.OAuthCallback)).Methods(http.MethodGet)
+	// device authorization page, where users approve command line tools
+	r.Path(deviceURL).Handler(sessions.RetrieveSession(a.sessionLoaders...)(a.VerifySession(httputil.HandlerFunc(a.Device)))).Methods(http.MethodGet, http.MethodPost)
 
 	// Proxy service endpoints
 	v := r.PathPrefix("/.pomerium").Subrouter()
@@ -63,10 +67,34 @@ func (a *Authenticate) Handler() http.Handler {
 	api := r.PathPrefix("/api").Subrouter()
 	api.Use(sessions.RetrieveSession(a.sessionLoaders...))
 	api.Path("/v1/refresh").Handler(httputil.HandlerFunc(a.RefreshAPI))
+	api.Path("/v1/tokens").Handler(middleware.ValidateSignature(a.sharedKey)(httputil.HandlerFunc(a.TokensAPI))).Methods(http.MethodPost)
+	api.Path("/v1/device/code").Handler(httputil.HandlerFunc(a.DeviceCodeAPI)).Methods(http.MethodPost)
+	api.Path("/v1/device/token").Handler(httputil.HandlerFunc(a.DeviceTokenAPI)).Methods(http.MethodPost)
+
+	// session inventory api endpoints, used by the proxy service
+	api.Path("/v1/sessions").Handler(middleware.ValidateSignature(a.sharedKey)(httputil.HandlerFunc(a.SessionsAPI))).Methods(http.MethodGet)
+	api.Path("/v1/sessions/revoke").Handler(middleware.ValidateSignature(a.sharedKey)(httputil.HandlerFunc(a.RevokeSessionAPI))).Methods(http.MethodPost)
+	api.Path("/v1/sessions/revoked").Handler(middleware.ValidateSignature(a.sharedKey)(httputil.HandlerFunc(a.RevokedSessionsAPI))).Methods(http.MethodGet)
 
 	return r
 }
 
+// skipCSRFForSignedAPIRequests is middleware that exempts api requests
+// signed with the shared key, made by the other pomerium services rather than
+// by browsers, from CSRF protection. Device authorization requests, which do
+// not use cookies, are exempt too.
+func (a *Authenticate) skipCSRFForSignedAPIRequests(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") && middleware.ValidateRequestURL(r, a.sharedKey) == nil {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		if r.URL.Path == deviceCodeURL || r.URL.Path == deviceTokenURL {
+			r = csrf.UnsafeSkipCheck(r)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // VerifySession is the middleware used to enforce a valid authentication
 // session state is attached to the users's request context.
 func (a *Authenticate) VerifySession(next http.Handler) http.Handler {
@@ -94,6 +122,9 @@ func (a *Authenticate) refresh(w http.ResponseWriter, r *http.Request, s *sessio
 	if err != nil {
 		return fmt.Errorf("authenticate: refresh failed: %w", err)
 	}
+	if err := a.trackSession(r, newSession); err != nil {
+		return fmt.Errorf("authenticate: refresh failed: %w", err)
+	}
 	if err := a.sessionStore.SaveSession(w, r, newSession); err != nil {
 		return fmt.Errorf("authenticate: refresh save failed: %w", err)
 	}
@@ -107,6 +138,15 @@ func (a *Authenticate) RobotsTxt(w http.ResponseWriter, r *http.Request) {
 	fmt.Fprintf(w, "User-agent: *\nDisallow: /")
 }
 
+// JWKS returns the public key set of the key signing the proxy's identity
+// headers, so that upstreams can verify them.
+func (a *Authenticate) JWKS(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(a.jwks)
+}
+
 // SignIn handles to authenticating a user.
 func (a *Authenticate) SignIn(w http.ResponseWriter, r *http.Request) error {
 	redirectURL, err := urlutil.ParseAndValidateURL(r.FormValue(urlutil.QueryRedirectURI))
@@ -164,6 +204,16 @@ func (a *Authenticate) SignIn(w http.ResponseWriter, r *http.Request) error {
 		callbackParams.Set(urlutil.QueryIsProgrammatic, "true")
 	}
 
+	// the proxy forwards the user's identity provider tokens to upstreams
+	// for some routes, and so needs the full session to refresh them
+	if r.FormValue(urlutil.QueryForwardTokens) == "true" {
+		encTokens, err := a.sharedEncryptedEncoder.Marshal(s)
+		if err != nil {
+			return httputil.NewError(http.StatusBadRequest, err)
+		}
+		callbackParams.Set(urlutil.QuerySessionTokens, string(encTokens))
+	}
+
 	// sign the route session, as a JWT
 	signedJWT, err := a.sharedEncoder.Marshal(newSession.RouteSession())
 	if err != nil {
@@ -195,6 +245,12 @@ func (a *Authenticate) SignOut(w http.ResponseWriter, r *http.Request) error {
 		return httputil.NewError(http.StatusBadRequest, err)
 	}
 	a.sessionStore.ClearSession(w, r)
+	// the session is revoked, so that it is signed out of every route
+	if session.ID != "" {
+		if err := a.inventory.Revoke(r.Context(), session.ID, session.Subject); err != nil {
+			log.FromRequest(r).Debug().Err(err).Msg("authenticate: sign out, revoke session")
+		}
+	}
 	err = a.provider.Revoke(r.Context(), session.AccessToken)
 	if err != nil {
 		return httputil.NewError(http.StatusBadRequest, err)
@@ -300,7 +356,11 @@ func (a *Authenticate) getOAuthCallback(w http.ResponseWriter, r *http.Request)
 		return nil, httputil.NewError(http.StatusBadRequest, err)
 	}
 
-	// OK. Looks good so let's persist our user session
+	// OK. Looks good so let's track and persist our user session
+	session.ID = cryptutil.NewRandomStringN(32)
+	if err := a.trackSession(r, session); err != nil {
+		return nil, fmt.Errorf("failed tracking new session: %w", err)
+	}
 	if err := a.sessionStore.SaveSession(w, r, session); err != nil {
 		return nil, fmt.Errorf("failed saving new session: %w", err)
 	}
@@ -319,6 +379,9 @@ func (a *Authenticate) RefreshAPI(w http.ResponseWriter, r *http.Request) error
 	if err != nil {
 		return err
 	}
+	if err := a.trackSession(r, newSession); err != nil {
+		return httputil.NewError(http.StatusUnauthorized, err)
+	}
 	newSession = newSession.NewSession(s.Issuer, s.Audience)
 
 	encSession, err := a.encryptedEncoder.Marshal(newSession)
@@ -345,3 +408,105 @@ func (a *Authenticate) RefreshAPI(w http.ResponseWriter, r *http.Request) error
 	w.Write(jsonResponse)
 	return nil
 }
+
+// TokensAPI refreshes the identity provider tokens of a session the proxy
+// service forwards to upstreams. The session, encrypted with the shared key,
+// is posted as the `pomerium_session_tokens` form value and, if successful,
+// the refreshed session is returned in kind as JSON.
+func (a *Authenticate) TokensAPI(w http.ResponseWriter, r *http.Request) error {
+	var s sessions.State
+	if err := a.sharedEncryptedEncoder.Unmarshal([]byte(r.PostFormValue(urlutil.QuerySessionTokens)), &s); err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	newSession, err := a.provider.Refresh(r.Context(), &s)
+	if err != nil {
+		return httputil.NewError(http.StatusUnauthorized, err)
+	}
+	encTokens, err := a.sharedEncryptedEncoder.Marshal(newSession)
+	if err != nil {
+		return err
+	}
+	var response struct {
+		SessionTokens string `json:"session_tokens"`
+	}
+	response.SessionTokens = string(encTokens)
+
+	jsonResponse, err := json.Marshal(&response)
+	if err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonResponse)
+	return nil
+}
+
+// trackSession adds the user's new, or refreshed, session to the inventory.
+// Sessions created before sessions were tracked, which have no id, are not.
+func (a *Authenticate) trackSession(r *http.Request, s *sessions.State) error {
+	if s.ID == "" {
+		return nil
+	}
+	return a.inventory.Add(r.Context(), sessions.NewRecord(r, s, time.Now().Add(a.cookieOptions.Expire)))
+}
+
+// SessionsAPI returns the active sessions of the user given by the `user`
+// query parameter as JSON.
+func (a *Authenticate) SessionsAPI(w http.ResponseWriter, r *http.Request) error {
+	user := r.FormValue("user")
+	if user == "" {
+		return httputil.NewError(http.StatusBadRequest, errors.New("authenticate: user is required"))
+	}
+	records, err := a.inventory.List(r.Context(), user)
+	if err != nil {
+		return err
+	}
+	var response struct {
+		Sessions []sessions.Record `json:"sessions"`
+	}
+	response.Sessions = records
+	if response.Sessions == nil {
+		response.Sessions = []sessions.Record{}
+	}
+	return writeJSON(w, &response)
+}
+
+// RevokeSessionAPI revokes the session given by the `id` form value. If the
+// `user` form value is set, the session must be theirs.
+func (a *Authenticate) RevokeSessionAPI(w http.ResponseWriter, r *http.Request) error {
+	id := r.PostFormValue("id")
+	if id == "" {
+		return httputil.NewError(http.StatusBadRequest, errors.New("authenticate: session id is required"))
+	}
+	if err := a.inventory.Revoke(r.Context(), id, r.PostFormValue("user")); err != nil {
+		if errors.Is(err, sessions.ErrUnknownSession) {
+			return httputil.NewError(http.StatusNotFound, err)
+		}
+		return err
+	}
+	log.FromRequest(r).Info().Str("session", id).Msg("authenticate: session revoked")
+	return writeJSON(w, &struct{}{})
+}
+
+// RevokedSessionsAPI returns the ids of all revoked sessions as JSON, for
+// the proxy service to reject.
+func (a *Authenticate) RevokedSessionsAPI(w http.ResponseWriter, r *http.Request) error {
+	ids, err := a.inventory.RevokedIDs(r.Context())
+	if err != nil {
+		return err
+	}
+	var response struct {
+		Revoked []string `json:"revoked"`
+	}
+	response.Revoked = ids
+	return writeJSON(w, &response)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) error {
+	jsonResponse, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(jsonResponse)
+	return nil
+}
@@ -1,13 +1,17 @@
 package authenticate
 
 import (
+	"context"
 	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +19,7 @@ import (
 
 	"github.com/pomerium/pomerium/internal/cryptutil"
 	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/encoding/ecjson"
 	"github.com/pomerium/pomerium/internal/encoding/mock"
 	"github.com/pomerium/pomerium/internal/frontend"
 	"github.com/pomerium/pomerium/internal/identity"
@@ -55,6 +60,24 @@ func TestAuthenticate_RobotsTxt(t *testing.T) {
 	}
 }
 
+func TestAuthenticate_JWKS(t *testing.T) {
+	t.Parallel()
+	auth := testAuthenticate()
+	auth.jwks = []byte(`{"keys":[]}`)
+	r := httptest.NewRequest(http.MethodGet, "https://auth.example.com/.well-known/pomerium/jwks.json", nil)
+	w := httptest.NewRecorder()
+	auth.Handler().ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v", w.Code, http.StatusOK)
+	}
+	if got := w.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type = %q, want %q", got, "application/json")
+	}
+	if diff := cmp.Diff(`{"keys":[]}`, w.Body.String()); diff != "" {
+		t.Errorf("body = %s", diff)
+	}
+}
+
 func TestAuthenticate_Handler(t *testing.T) {
 	auth := testAuthenticate()
 
@@ -122,6 +145,8 @@ func TestAuthenticate_SignIn(t *testing.T) {
 		{"good with callback uri set", "https", "corp.example.example", map[string]string{urlutil.QueryCallbackURI: "https://some.example/", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusFound},
 		{"bad callback uri set", "https", "corp.example.example", map[string]string{urlutil.QueryCallbackURI: "^", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusBadRequest},
 		{"good programmatic request", "https", "corp.example.example", map[string]string{urlutil.QueryIsProgrammatic: "true", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusFound},
+		{"good forward tokens", "https", "corp.example.example", map[string]string{urlutil.QueryForwardTokens: "true", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusFound},
+		{"forward tokens encoder error", "https", "corp.example.example", map[string]string{urlutil.QueryForwardTokens: "true", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{MarshalError: errors.New("error")}, http.StatusBadRequest},
 		{"good additional audience", "https", "corp.example.example", map[string]string{urlutil.QueryForwardAuth: "x.y.z", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusFound},
 		{"good user impersonate", "https", "corp.example.example", map[string]string{urlutil.QueryImpersonateAction: "set", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusFound},
 		{"bad user impersonate save failure", "https", "corp.example.example", map[string]string{urlutil.QueryImpersonateAction: "set", urlutil.QueryRedirectURI: "https://dst.some.example/"}, &sessions.MockSessionStore{SaveError: errors.New("err"), Session: &sessions.State{Email: "user@pomerium.io", AccessToken: &oauth2.Token{Expiry: time.Now().Add(10 * time.Second)}}}, identity.MockProvider{}, &mock.Encoder{}, http.StatusBadRequest},
@@ -140,6 +165,7 @@ func TestAuthenticate_SignIn(t *testing.T) {
 					Name:   "cookie",
 					Domain: "foo",
 				},
+				sharedEncryptedEncoder: tt.encoder,
 			}
 			uri := &url.URL{Scheme: tt.scheme, Host: tt.host}
 
@@ -269,10 +295,12 @@ func TestAuthenticate_OAuthCallback(t *testing.T) {
 			}
 			authURL, _ := url.Parse(tt.authenticateURL)
 			a := &Authenticate{
-				RedirectURL:  authURL,
-				sessionStore: tt.session,
-				provider:     tt.provider,
-				cookieCipher: aead,
+				RedirectURL:   authURL,
+				sessionStore:  tt.session,
+				provider:      tt.provider,
+				cookieCipher:  aead,
+				cookieOptions: &sessions.CookieOptions{Expire: time.Hour},
+				inventory:     sessions.NewMemoryInventory(time.Hour),
 			}
 			u, _ := url.Parse("/oauthGet")
 			params, _ := url.ParseQuery(u.RawQuery)
@@ -423,3 +451,154 @@ func TestAuthenticate_RefreshAPI(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthenticate_TokensAPI(t *testing.T) {
+	t.Parallel()
+	aead, err := chacha20poly1305.NewX(cryptutil.NewKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoder := ecjson.New(aead)
+	session, err := encoder.Marshal(&sessions.State{Subject: "user", AccessToken: &oauth2.Token{AccessToken: "old", RefreshToken: "refresh"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		tokens   string
+		provider identity.Authenticator
+		encoder  encoding.MarshalUnmarshaler
+
+		wantStatus      int
+		wantAccessToken string
+	}{
+		{"good", string(session), identity.MockProvider{RefreshResponse: sessions.State{Subject: "user", AccessToken: &oauth2.Token{AccessToken: "new"}}}, encoder, http.StatusOK, "new"},
+		{"missing tokens", "", identity.MockProvider{}, encoder, http.StatusBadRequest, ""},
+		{"malformed tokens", "garbage", identity.MockProvider{}, encoder, http.StatusBadRequest, ""},
+		{"refresh error", string(session), identity.MockProvider{RefreshError: errors.New("error")}, encoder, http.StatusUnauthorized, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Authenticate{
+				provider:               tt.provider,
+				sharedEncryptedEncoder: tt.encoder,
+			}
+			form := url.Values{urlutil.QuerySessionTokens: {tt.tokens}}
+			r := httptest.NewRequest(http.MethodPost, "/api/v1/tokens", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			httputil.HandlerFunc(a.TokensAPI).ServeHTTP(w, r)
+			if status := w.Code; status != tt.wantStatus {
+				t.Fatalf("TokensAPI() status = %v, want %v\n%v", status, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			var response struct {
+				SessionTokens string `json:"session_tokens"`
+			}
+			if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+				t.Fatal(err)
+			}
+			var got sessions.State
+			if err := encoder.Unmarshal([]byte(response.SessionTokens), &got); err != nil {
+				t.Fatal(err)
+			}
+			if got.AccessToken.AccessToken != tt.wantAccessToken {
+				t.Errorf("TokensAPI() access token = %q, want %q", got.AccessToken.AccessToken, tt.wantAccessToken)
+			}
+		})
+	}
+}
+
+func TestAuthenticate_SessionsAPI(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		form   url.Values
+
+		wantStatus int
+		wantBody   string
+	}{
+		{"list", http.MethodGet, "/api/v1/sessions", url.Values{"user": {"user"}}, http.StatusOK, `{"sessions":[{"id":"a","user":"user","user_agent":"browser","created_at":"2020-01-01T00:00:00Z","expiry":"2030-01-01T00:00:00Z"}]}`},
+		{"list no sessions", http.MethodGet, "/api/v1/sessions", url.Values{"user": {"nobody"}}, http.StatusOK, `{"sessions":[]}`},
+		{"list missing user", http.MethodGet, "/api/v1/sessions", nil, http.StatusBadRequest, ""},
+		{"revoke", http.MethodPost, "/api/v1/sessions/revoke", url.Values{"id": {"a"}, "user": {"user"}}, http.StatusOK, `{}`},
+		{"revoke any user's session", http.MethodPost, "/api/v1/sessions/revoke", url.Values{"id": {"b"}}, http.StatusOK, `{}`},
+		{"revoke other user's session", http.MethodPost, "/api/v1/sessions/revoke", url.Values{"id": {"b"}, "user": {"user"}}, http.StatusNotFound, ""},
+		{"revoke unknown session", http.MethodPost, "/api/v1/sessions/revoke", url.Values{"id": {"c"}}, http.StatusNotFound, ""},
+		{"revoke missing id", http.MethodPost, "/api/v1/sessions/revoke", nil, http.StatusBadRequest, ""},
+		{"revoked", http.MethodGet, "/api/v1/sessions/revoked", nil, http.StatusOK, `{"revoked":["z"]}`},
+		{"unsigned", http.MethodGet, "/api/v1/sessions/revoked", url.Values{"unsigned": {"true"}}, http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			a := testAuthenticate()
+			inventory := sessions.NewMemoryInventory(time.Hour)
+			created := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+			expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+			for _, r := range []sessions.Record{
+				{ID: "a", User: "user", UserAgent: "browser", CreatedAt: created, Expiry: expiry},
+				{ID: "b", User: "other", CreatedAt: created, Expiry: expiry},
+				{ID: "z", User: "other", CreatedAt: created, Expiry: now.Add(time.Hour)},
+			} {
+				inventory.Add(context.Background(), r)
+			}
+			inventory.Revoke(context.Background(), "z", "")
+			a.inventory = inventory
+
+			u := &url.URL{Scheme: "https", Host: "authenticate.example", Path: tt.path}
+			var body io.Reader
+			if tt.method == http.MethodGet {
+				u.RawQuery = tt.form.Encode()
+			} else {
+				body = strings.NewReader(tt.form.Encode())
+			}
+			if tt.form.Get("unsigned") == "" {
+				u = urlutil.NewSignedURL(a.sharedKey, u).Sign()
+			}
+			r := httptest.NewRequest(tt.method, u.String(), body)
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("Accept", "application/json")
+			w := httptest.NewRecorder()
+			a.Handler().ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v\n%v", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantBody != "" {
+				if diff := cmp.Diff(tt.wantBody, w.Body.String()); diff != "" {
+					t.Errorf("wrong body\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestAuthenticate_SignOut_revokesSession(t *testing.T) {
+	t.Parallel()
+	a := testAuthenticate()
+	a.provider = identity.MockProvider{}
+	a.inventory = sessions.NewMemoryInventory(time.Hour)
+	session := &sessions.State{ID: "id", Subject: "user"}
+	a.sessionStore = &sessions.MockSessionStore{Session: session}
+
+	r := httptest.NewRequest(http.MethodGet, "/sign_out?"+urlutil.QueryRedirectURI+"=https://corp.example", nil)
+	if err := a.trackSession(r, session); err != nil {
+		t.Fatal(err)
+	}
+	r = r.WithContext(sessions.NewContext(r.Context(), session, nil))
+	w := httptest.NewRecorder()
+	httputil.HandlerFunc(a.SignOut).ServeHTTP(w, r)
+	if w.Code != http.StatusFound {
+		t.Fatalf("status = %v, want %v\n%v", w.Code, http.StatusFound, w.Body.String())
+	}
+	if !a.inventory.IsRevoked(r.Context(), session) {
+		t.Error("signed out session not revoked")
+	}
+}
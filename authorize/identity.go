@@ -62,17 +62,20 @@ func newIdentityWhitelistMap(policies []config.Policy, admins []string) *whiteli
 	var wl whitelist
 	wl.access = make(map[string]bool, len(policies)*3)
 	for _, p := range policies {
+		// routes sharing a host, but not a path prefix, are authorized
+		// separately
+		route := p.RouteID()
 		for _, group := range p.AllowedGroups {
-			wl.PutGroup(p.Source.Host, group)
-			log.Debug().Str("route", p.Source.Host).Str("group", group).Msg("add group")
+			wl.PutGroup(route, group)
+			log.Debug().Str("route", route).Str("group", group).Msg("add group")
 		}
 		for _, domain := range p.AllowedDomains {
-			wl.PutDomain(p.Source.Host, domain)
-			log.Debug().Str("route", p.Source.Host).Str("domain", domain).Msg("add domain")
+			wl.PutDomain(route, domain)
+			log.Debug().Str("route", route).Str("domain", domain).Msg("add domain")
 		}
 		for _, email := range p.AllowedEmails {
-			wl.PutEmail(p.Source.Host, email)
-			log.Debug().Str("route", p.Source.Host).Str("email", email).Msg("add email")
+			wl.PutEmail(route, email)
+			log.Debug().Str("route", route).Str("email", email).Msg("add email")
 		}
 	}
 
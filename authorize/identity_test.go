@@ -51,6 +51,10 @@ func Test_IdentityWhitelistMap(t *testing.T) {
 		{"invalid user email", []config.Policy{{From: "https://from.example", To: "https://to.example", AllowedEmails: []string{"user@example.com"}}}, "from.example", &Identity{Email: "user2@example.com"}, nil, false},
 		{"empty everything", []config.Policy{{From: "https://from.example", To: "https://to.example"}}, "from.example", &Identity{Email: "user2@example.com"}, nil, false},
 		{"empty policy", []config.Policy{}, "from.example", &Identity{Email: "user2@example.com"}, nil, false},
+		// routes sharing a host are authorized separately
+		{"prefix route", []config.Policy{{From: "https://from.example", To: "https://to.example", Prefix: "/admin", AllowedEmails: []string{"alice@example.com"}}, {From: "https://from.example", To: "https://to.example", AllowedDomains: []string{"example.com"}}}, "from.example/admin", &Identity{Email: "alice@example.com"}, nil, true},
+		{"prefix route denied", []config.Policy{{From: "https://from.example", To: "https://to.example", Prefix: "/admin", AllowedEmails: []string{"alice@example.com"}}, {From: "https://from.example", To: "https://to.example", AllowedDomains: []string{"example.com"}}}, "from.example/admin", &Identity{Email: "bob@example.com"}, nil, false},
+		{"host route", []config.Policy{{From: "https://from.example", To: "https://to.example", Prefix: "/admin", AllowedEmails: []string{"alice@example.com"}}, {From: "https://from.example", To: "https://to.example", AllowedDomains: []string{"example.com"}}}, "from.example", &Identity{Email: "bob@example.com"}, nil, true},
 		// impersonation related
 		{"admin not impersonating allowed", []config.Policy{{From: "https://from.example", To: "https://to.example", AllowedDomains: []string{"example.com"}}}, "from.example", &Identity{Email: "admin@example.com"}, []string{"admin@example.com"}, true},
 		{"admin not impersonating denied", []config.Policy{{From: "https://from.example", To: "https://to.example", AllowedDomains: []string{"example.com"}}}, "from.example", &Identity{Email: "admin@admin-domain.com"}, []string{"admin@admin-domain.com"}, false},
@@ -0,0 +1,161 @@
+package main
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+const (
+	deviceCodePath  = "/api/v1/device/code"
+	deviceTokenPath = "/api/v1/device/token"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+)
+
+func runLogin(args []string) error {
+	fs := flag.NewFlagSet("login", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: pomerium-cli login [flags] <route url>...")
+		fs.PrintDefaults()
+	}
+	authenticateURL := fs.String("authenticate-url", "", "the authenticate service's url, e.g. https://authenticate.corp.example.com")
+	skipVerify := fs.Bool("tls-skip-verify", false, "skip verification of the authenticate service's certificate")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return errors.New("login: expected a route url")
+	}
+	u, err := urlutil.ParseAndValidateURL(*authenticateURL)
+	if err != nil {
+		return fmt.Errorf("login: bad authenticate url %w", err)
+	}
+
+	l := &deviceLogin{
+		authenticateURL: u,
+		client: &http.Client{Transport: &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: *skipVerify},
+		}},
+		sleep: time.Sleep,
+	}
+	tokens, err := l.login(fs.Args(), os.Stderr)
+	if err != nil {
+		return err
+	}
+	// the tokens are printed as returned, to be used by scripts
+	_, err = os.Stdout.Write(append(tokens, '\n'))
+	return err
+}
+
+// deviceLogin signs in to pomerium using the device authorization grant: the
+// user approves the sign in in their browser, while the device polls for the
+// resulting programmatic session.
+//
+// https://tools.ietf.org/html/rfc8628
+type deviceLogin struct {
+	authenticateURL *url.URL
+	client          *http.Client
+	sleep           func(time.Duration)
+}
+
+// deviceError is an oauth2 error response.
+type deviceError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *deviceError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("login: %s: %s", e.Code, e.Description)
+	}
+	return "login: " + e.Code
+}
+
+// login requests a programmatic session for the given routes, writing the
+// instructions for the user to w, and returns the authenticate service's
+// token response once the user has approved it.
+func (l *deviceLogin) login(routes []string, w io.Writer) ([]byte, error) {
+	var code struct {
+		DeviceCode              string `json:"device_code"`
+		UserCode                string `json:"user_code"`
+		VerificationURI         string `json:"verification_uri"`
+		VerificationURIComplete string `json:"verification_uri_complete"`
+		ExpiresIn               int    `json:"expires_in"`
+		Interval                int    `json:"interval"`
+	}
+	body, err := l.post(deviceCodePath, url.Values{"resource": routes})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(body, &code); err != nil {
+		return nil, fmt.Errorf("login: bad device code response: %w", err)
+	}
+	fmt.Fprintf(w, "To sign in, visit %s and enter the code %s\n", code.VerificationURI, code.UserCode)
+	fmt.Fprintf(w, "or visit %s\n", code.VerificationURIComplete)
+
+	interval := time.Duration(code.Interval) * time.Second
+	expires := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	for time.Now().Before(expires) {
+		l.sleep(interval)
+		body, err := l.post(deviceTokenPath, url.Values{
+			"grant_type":  {deviceGrantType},
+			"device_code": {code.DeviceCode},
+		})
+		var de *deviceError
+		switch {
+		case err == nil:
+			return body, nil
+		case !errors.As(err, &de):
+			return nil, err
+		case de.Code == "authorization_pending":
+		case de.Code == "slow_down":
+			interval += 5 * time.Second
+		default:
+			return nil, err
+		}
+	}
+	return nil, errors.New("login: the code expired before sign in was approved")
+}
+
+// post posts the form to the authenticate service's endpoint, returning the
+// response body, or a *deviceError for oauth2 error responses.
+func (l *deviceLogin) post(path string, form url.Values) ([]byte, error) {
+	endpoint := l.authenticateURL.ResolveReference(&url.URL{Path: path})
+	req, err := http.NewRequest(http.MethodPost, endpoint.String(), strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("User-Agent", version.UserAgent())
+	res, err := l.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusOK {
+		return body, nil
+	}
+	var de deviceError
+	if json.Unmarshal(body, &de) == nil && de.Code != "" {
+		return nil, &de
+	}
+	return nil, fmt.Errorf("login: %s %s", res.Status, body)
+}
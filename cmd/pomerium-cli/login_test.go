@@ -0,0 +1,81 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func Test_deviceLogin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		// responses are the token endpoint's error codes, in order, before
+		// it returns the tokens
+		responses []string
+
+		wantErr       bool
+		wantIntervals []time.Duration
+	}{
+		{"approved", []string{"authorization_pending", "authorization_pending"}, false, []time.Duration{5 * time.Second, 5 * time.Second, 5 * time.Second}},
+		{"slow down", []string{"slow_down", "authorization_pending"}, false, []time.Duration{5 * time.Second, 10 * time.Second, 10 * time.Second}},
+		{"denied", []string{"authorization_pending", "access_denied"}, true, []time.Duration{5 * time.Second, 5 * time.Second}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			polls := 0
+			// a fake authenticate service's device authorization endpoints
+			mux := http.NewServeMux()
+			mux.HandleFunc(deviceCodePath, func(w http.ResponseWriter, r *http.Request) {
+				if r.PostFormValue("resource") != "https://httpbin.corp.example" {
+					http.Error(w, "bad resource", http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, `{"device_code":"device","user_code":"BCDF-GHJK","verification_uri":"https://auth.example/device","expires_in":600,"interval":5}`)
+			})
+			mux.HandleFunc(deviceTokenPath, func(w http.ResponseWriter, r *http.Request) {
+				if r.PostFormValue("grant_type") != deviceGrantType || r.PostFormValue("device_code") != "device" {
+					http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+					return
+				}
+				defer func() { polls++ }()
+				if polls < len(tt.responses) {
+					http.Error(w, fmt.Sprintf(`{"error":%q}`, tt.responses[polls]), http.StatusBadRequest)
+					return
+				}
+				fmt.Fprint(w, `{"jwt":"jwt","refresh_token":"refresh"}`)
+			})
+			srv := httptest.NewServer(mux)
+			defer srv.Close()
+
+			u, _ := url.Parse(srv.URL)
+			var intervals []time.Duration
+			l := &deviceLogin{
+				authenticateURL: u,
+				client:          srv.Client(),
+				sleep:           func(d time.Duration) { intervals = append(intervals, d) },
+			}
+			var out bytes.Buffer
+			got, err := l.login([]string{"https://httpbin.corp.example"}, &out)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("login() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && string(got) != `{"jwt":"jwt","refresh_token":"refresh"}` {
+				t.Errorf("login() = %s", got)
+			}
+			if !strings.Contains(out.String(), "https://auth.example/device") || !strings.Contains(out.String(), "BCDF-GHJK") {
+				t.Errorf("login() instructions = %q", out.String())
+			}
+			if fmt.Sprint(intervals) != fmt.Sprint(tt.wantIntervals) {
+				t.Errorf("login() polled after %v, want %v", intervals, tt.wantIntervals)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package main // import "github.com/pomerium/pomerium/cmd/pomerium-cli"
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+const usage = `usage: pomerium-cli <command> [flags]
+
+commands:
+  login     sign in, from a browser, for a programmatic session token
+  service-account
+            mint a service account token, signed with the shared secret
+  tcp       tunnel local TCP connections through pomerium
+  version   print the version`
+
+func main() {
+	if err := run(os.Args[1:]); err != nil {
+		log.Fatal().Err(err).Msg("cmd/pomerium-cli")
+	}
+}
+
+func run(args []string) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	switch args[0] {
+	case "login":
+		return runLogin(args[1:])
+	case "service-account":
+		return runServiceAccount(args[1:])
+	case "tcp":
+		return runTCP(args[1:])
+	case "version":
+		fmt.Println(version.FullVersion())
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", args[0], usage)
+	}
+}
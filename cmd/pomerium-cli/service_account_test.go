@@ -0,0 +1,68 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pomerium/pomerium/internal/cryptutil"
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/sessions"
+)
+
+func Test_newServiceAccount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		id       string
+		user     string
+		email    string
+		expiry   time.Duration
+		wantUser string
+		wantErr  bool
+	}{
+		{"email", "", "", "ci@corp.example", time.Hour, "ci@corp.example", false},
+		{"user and email", "id", "ci", "ci@corp.example", time.Hour, "ci", false},
+		{"no identity", "", "", "", time.Hour, "", true},
+		{"no expiry", "", "", "ci@corp.example", 0, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newServiceAccount(tt.id, tt.user, tt.email, nil, nil, tt.expiry)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newServiceAccount() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.Subject != tt.wantUser || got.ID == "" || (tt.id != "" && got.ID != tt.id) {
+				t.Errorf("newServiceAccount() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_signServiceAccount(t *testing.T) {
+	t.Parallel()
+	sharedSecret := cryptutil.NewBase64Key()
+	s, err := newServiceAccount("id", "", "ci@corp.example", splitList("deployers, admins,"), nil, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := signServiceAccount("bad", s); err == nil {
+		t.Error("signed with a bad shared secret")
+	}
+	token, err := signServiceAccount(sharedSecret, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the proxy loads sessions with the same signer
+	verifier, _ := jws.NewHS256Signer([]byte(sharedSecret), "authenticate.corp.example")
+	var got sessions.State
+	if err := verifier.Unmarshal([]byte(token), &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(s, &got, cmp.AllowUnexported(sessions.State{})); diff != "" {
+		t.Errorf("signed service account = %s", diff)
+	}
+}
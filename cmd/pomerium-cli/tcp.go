@@ -0,0 +1,152 @@
+package main
+
+import (
+	"bufio"
+	"crypto/tls"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/tcputil"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+func runTCP(args []string) error {
+	fs := flag.NewFlagSet("tcp", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: pomerium-cli tcp [flags] <destination host:port>")
+		fs.PrintDefaults()
+	}
+	listen := fs.String("listen", "127.0.0.1:0", "local address to accept connections on")
+	pomeriumURL := fs.String("pomerium-url", "", "pomerium's url, if not the destination's hostname on port 443")
+	token := fs.String("token", os.Getenv("POMERIUM_TOKEN"), "programmatic session token, defaults to $POMERIUM_TOKEN")
+	skipVerify := fs.Bool("tls-skip-verify", false, "skip verification of pomerium's certificate")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return errors.New("tcp: expected a destination")
+	}
+
+	tun, err := newTCPTunnel(fs.Arg(0), *pomeriumURL, *token, *skipVerify)
+	if err != nil {
+		return err
+	}
+	li, err := net.Listen("tcp", *listen)
+	if err != nil {
+		return err
+	}
+	defer li.Close()
+	log.Info().Str("addr", li.Addr().String()).Str("destination", tun.destination).Msg("cmd/pomerium-cli: listening")
+	return tun.serve(li)
+}
+
+// tcpTunnel tunnels connections to a TCP route through pomerium using HTTP
+// CONNECT requests, authenticated with a programmatic session token.
+type tcpTunnel struct {
+	// destination is the host and port of the route, e.g. `redis.corp.example.com:6379`
+	destination string
+	// pomeriumAddr is the host and port pomerium is reached on
+	pomeriumAddr string
+	// tlsConfig is nil if pomerium is reached over plain http
+	tlsConfig *tls.Config
+	token     string
+}
+
+func newTCPTunnel(destination, rawPomeriumURL, token string, skipVerify bool) (*tcpTunnel, error) {
+	host, _, err := net.SplitHostPort(destination)
+	if err != nil {
+		return nil, fmt.Errorf("tcp: bad destination %s: %w", destination, err)
+	}
+	u := &url.URL{Scheme: "https", Host: host}
+	if rawPomeriumURL != "" {
+		u, err = urlutil.ParseAndValidateURL(rawPomeriumURL)
+		if err != nil {
+			return nil, fmt.Errorf("tcp: bad pomerium url %w", err)
+		}
+	}
+	t := &tcpTunnel{destination: destination, token: token}
+	switch u.Scheme {
+	case "https":
+		t.pomeriumAddr = hostPort(u, "443")
+		t.tlsConfig = &tls.Config{
+			ServerName:         u.Hostname(),
+			InsecureSkipVerify: skipVerify,
+			// the tunnel hijacks an HTTP/1.1 connection
+			NextProtos: []string{"http/1.1"},
+		}
+	case "http":
+		t.pomeriumAddr = hostPort(u, "80")
+	default:
+		return nil, fmt.Errorf("tcp: unsupported pomerium url scheme %s", u.Scheme)
+	}
+	return t, nil
+}
+
+func hostPort(u *url.URL, defaultPort string) string {
+	if u.Port() != "" {
+		return u.Host
+	}
+	return net.JoinHostPort(u.Hostname(), defaultPort)
+}
+
+func (t *tcpTunnel) serve(li net.Listener) error {
+	for {
+		conn, err := li.Accept()
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := t.handle(conn); err != nil {
+				log.Error().Err(err).Str("destination", t.destination).Msg("cmd/pomerium-cli: tunnel failed")
+			}
+		}()
+	}
+}
+
+// handle tunnels a single local connection.
+func (t *tcpTunnel) handle(local net.Conn) error {
+	defer local.Close()
+	remote, err := t.dial()
+	if err != nil {
+		return err
+	}
+	defer remote.Close()
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: t.destination},
+		Host:   t.destination,
+		Header: make(http.Header),
+	}
+	if t.token != "" {
+		req.Header.Set("Authorization", "Pomerium "+t.token)
+	}
+	if err := req.Write(remote); err != nil {
+		return err
+	}
+	br := bufio.NewReader(remote)
+	res, err := http.ReadResponse(br, req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("tcp: pomerium refused the tunnel: %s", res.Status)
+	}
+	// the buffered reader may hold bytes the upstream sent after the response
+	tcputil.Splice(local, local, remote, br)
+	return nil
+}
+
+func (t *tcpTunnel) dial() (net.Conn, error) {
+	if t.tlsConfig != nil {
+		return tls.Dial("tcp", t.pomeriumAddr, t.tlsConfig)
+	}
+	return net.Dial("tcp", t.pomeriumAddr)
+}
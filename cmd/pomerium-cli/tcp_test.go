@@ -0,0 +1,109 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_newTCPTunnel(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		destination string
+		pomeriumURL string
+		wantAddr    string
+		wantTLS     bool
+		wantErr     bool
+	}{
+		{"destination host", "redis.corp.example:6379", "", "redis.corp.example:443", true, false},
+		{"pomerium url", "redis.corp.example:6379", "https://pomerium.corp.example", "pomerium.corp.example:443", true, false},
+		{"pomerium url with port", "redis.corp.example:6379", "https://pomerium.corp.example:8443", "pomerium.corp.example:8443", true, false},
+		{"insecure pomerium url", "redis.corp.example:6379", "http://pomerium.corp.example", "pomerium.corp.example:80", false, false},
+		{"missing port", "redis.corp.example", "", "", false, true},
+		{"bad pomerium url", "redis.corp.example:6379", "pomerium", "", false, true},
+		{"bad pomerium scheme", "redis.corp.example:6379", "ftp://pomerium.corp.example", "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newTCPTunnel(tt.destination, tt.pomeriumURL, "", false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("newTCPTunnel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got.pomeriumAddr != tt.wantAddr {
+				t.Errorf("newTCPTunnel() addr = %s, want %s", got.pomeriumAddr, tt.wantAddr)
+			}
+			if (got.tlsConfig != nil) != tt.wantTLS {
+				t.Errorf("newTCPTunnel() tls = %v, want %v", got.tlsConfig != nil, tt.wantTLS)
+			}
+		})
+	}
+}
+
+func Test_tcpTunnel(t *testing.T) {
+	t.Parallel()
+	// a fake pomerium that echoes authenticated tunnels
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodConnect || r.Host != "redis.corp.example:6379" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Authorization") != "Pomerium token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n")
+		io.Copy(conn, brw)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{"good", "token", false},
+		{"unauthorized", "bad", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tun, err := newTCPTunnel("redis.corp.example:6379", srv.URL, tt.token, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			client, local := net.Pipe()
+			errc := make(chan error, 1)
+			go func() { errc <- tun.handle(local) }()
+
+			// writes to a net.Pipe block until they are read
+			go io.WriteString(client, "PING")
+			if !tt.wantErr {
+				buf := make([]byte, 4)
+				if _, err := io.ReadFull(client, buf); err != nil {
+					t.Fatal(err)
+				}
+				if string(buf) != "PING" {
+					t.Errorf("tunnel echoed %q, want %q", buf, "PING")
+				}
+				client.Close()
+			} else {
+				ioutil.ReadAll(client)
+			}
+			if err := <-errc; (err != nil) != tt.wantErr {
+				t.Errorf("handle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
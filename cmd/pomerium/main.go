@@ -60,6 +60,9 @@ func run() error {
 	}
 
 	r := newGlobalRouter(opt)
+	if err := setupTemplates(opt, r); err != nil {
+		return err
+	}
 	_, err = newAuthenticateService(*opt, r)
 	if err != nil {
 		return err
@@ -74,7 +77,7 @@ func run() error {
 		return err
 	}
 	if proxy != nil {
-		defer proxy.AuthorizeClient.Close()
+		defer proxy.Close()
 	}
 
 	opt.OnConfigChange(func(e fsnotify.Event) {
@@ -175,6 +178,20 @@ func newGlobalRouter(o *config.Options) *mux.Router {
 	return mux
 }
 
+// setupTemplates renders the error and sign in pages of all services with
+// the templates in the global templates directory, if set.
+func setupTemplates(opt *config.Options, r *mux.Router) error {
+	if opt.TemplatesDir == "" {
+		return nil
+	}
+	templates, err := httputil.LoadTemplates(opt.TemplatesDir)
+	if err != nil {
+		return err
+	}
+	r.Use(httputil.SetTemplates(templates))
+	return nil
+}
+
 func setupMetrics(opt *config.Options, wg *sync.WaitGroup) error {
 	if opt.MetricsAddr != "" {
 		handler, err := metrics.PrometheusHandler()
@@ -229,5 +246,7 @@ func httpServerOptions(opt *config.Options) *httputil.ServerOptions {
 		WriteTimeout:      opt.WriteTimeout,
 		ReadHeaderTimeout: opt.ReadHeaderTimeout,
 		IdleTimeout:       opt.IdleTimeout,
+		// gRPC clients of proxied routes may connect without TLS
+		H2C: config.IsProxy(opt.Services),
 	}
 }
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
+	"net"
 	"net/url"
 	"reflect"
 	"strconv"
@@ -130,12 +131,24 @@ type Options struct {
 	HeadersEnv string            `yaml:",omitempty"`
 	Headers    map[string]string `yaml:",omitempty"`
 
+	// TemplatesDir is a directory of html templates that override pomerium's
+	// error, forbidden and sign in pages.
+	TemplatesDir string `mapstructure:"templates_dir" yaml:"templates_dir,omitempty"`
+
 	// RefreshCooldown limits the rate a user can refresh her session
 	RefreshCooldown time.Duration `mapstructure:"refresh_cooldown" yaml:"refresh_cooldown,omitempty"`
 
 	//Routes                 map[string]string `mapstructure:"routes" yaml:"routes,omitempty"`
 	DefaultUpstreamTimeout time.Duration `mapstructure:"default_upstream_timeout" yaml:"default_upstream_timeout,omitempty"`
 
+	// DefaultMaxRequestBodyBytes limits the size of request bodies on routes
+	// that do not set their own limit. If unset, request bodies are unlimited.
+	DefaultMaxRequestBodyBytes int64 `mapstructure:"default_max_request_body_bytes" yaml:"default_max_request_body_bytes,omitempty"`
+
+	// RevokedServiceAccounts are the ids of service accounts that are no
+	// longer accepted, though they have not yet expired.
+	RevokedServiceAccounts []string `mapstructure:"revoked_service_accounts" yaml:"revoked_service_accounts,omitempty"`
+
 	// Address/Port to bind to for prometheus metrics
 	MetricsAddr string `mapstructure:"metrics_address" yaml:"metrics_address,omitempty"`
 
@@ -174,6 +187,13 @@ type Options struct {
 	ForwardAuthURLString string   `mapstructure:"forward_auth_url" yaml:"forward_auth_url,omitempty"`
 	ForwardAuthURL       *url.URL `yaml:",omitempty"`
 
+	// ForwardAuthTrustedProxies are the addresses, or CIDR ranges, of the
+	// fronting proxies trusted to describe the request to verify with
+	// headers, such as X-Forwarded-Host and X-Forwarded-Uri, instead of the
+	// `uri` query parameter.
+	ForwardAuthTrustedProxies    []string     `mapstructure:"forward_auth_trusted_proxies" yaml:"forward_auth_trusted_proxies,omitempty"`
+	ForwardAuthTrustedProxyCIDRs []*net.IPNet `yaml:",omitempty"`
+
 	viper *viper.Viper
 }
 
@@ -346,6 +366,24 @@ func (o *Options) parseHeaders() error {
 	return nil
 }
 
+// parseCIDR parses an address, or a CIDR range, as a range. A single address
+// is a range of one.
+func parseCIDR(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, fmt.Errorf("%q is not an ip address", s)
+		}
+		bits := 8 * net.IPv6len
+		if ip.To4() != nil {
+			ip, bits = ip.To4(), 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, cidr, err := net.ParseCIDR(s)
+	return cidr, err
+}
+
 // bindEnvs binds a viper instance to each env var of an Options struct based
 // on the mapstructure tag
 func bindEnvs(o *Options, v *viper.Viper) error {
@@ -438,6 +476,24 @@ func (o *Options) Validate() error {
 		o.ForwardAuthURL = u
 	}
 
+	if len(o.ForwardAuthTrustedProxies) != 0 {
+		if o.ForwardAuthURL == nil {
+			return errors.New("config: forward auth trusted proxies require a forward-auth-url")
+		}
+		o.ForwardAuthTrustedProxyCIDRs = nil
+		for _, proxy := range o.ForwardAuthTrustedProxies {
+			cidr, err := parseCIDR(proxy)
+			if err != nil {
+				return fmt.Errorf("config: bad forward auth trusted proxy %s : %w", proxy, err)
+			}
+			o.ForwardAuthTrustedProxyCIDRs = append(o.ForwardAuthTrustedProxyCIDRs, cidr)
+		}
+	}
+
+	if o.DefaultMaxRequestBodyBytes < 0 {
+		return errors.New("config: default max request body bytes cannot be negative")
+	}
+
 	if o.PolicyFile != "" {
 		return errors.New("config: policy file setting is deprecated")
 	}
@@ -38,6 +38,16 @@ func Test_Validate(t *testing.T) {
 
 	badPolicyFile := testOptions()
 	badPolicyFile.PolicyFile = "file"
+	badMaxRequestBodyBytes := testOptions()
+	badMaxRequestBodyBytes.DefaultMaxRequestBodyBytes = -1
+	trustedProxies := testOptions()
+	trustedProxies.ForwardAuthURLString = "https://fwdauth.example"
+	trustedProxies.ForwardAuthTrustedProxies = []string{"10.0.0.1", "172.16.0.0/12", "::1"}
+	badTrustedProxy := testOptions()
+	badTrustedProxy.ForwardAuthURLString = "https://fwdauth.example"
+	badTrustedProxy.ForwardAuthTrustedProxies = []string{"10.0.0"}
+	trustedProxiesNoForwardAuth := testOptions()
+	trustedProxiesNoForwardAuth.ForwardAuthTrustedProxies = []string{"10.0.0.1"}
 
 	tests := []struct {
 		name     string
@@ -49,6 +59,10 @@ func Test_Validate(t *testing.T) {
 		{"missing shared secret", badSecret, true},
 		{"missing shared secret but all service", badSecretAllServices, false},
 		{"policy file specified", badPolicyFile, true},
+		{"negative default max request body bytes", badMaxRequestBodyBytes, true},
+		{"forward auth trusted proxies", trustedProxies, false},
+		{"bad forward auth trusted proxy", badTrustedProxy, true},
+		{"forward auth trusted proxies without forward auth", trustedProxiesNoForwardAuth, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
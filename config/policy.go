@@ -4,7 +4,10 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"net/http"
 	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/pomerium/pomerium/internal/cryptutil"
@@ -14,7 +17,18 @@ import (
 // Policy contains route specific configuration and access settings.
 type Policy struct {
 	From string `mapstructure:"from" yaml:"from"`
-	To   string `mapstructure:"to" yaml:"to"`
+	To   string `mapstructure:"to" yaml:"to,omitempty"`
+
+	// DestinationGroups replace To on routes that split their users between
+	// weighted groups of destinations, as when moving a share of users to a
+	// new version of an upstream.
+	DestinationGroups []DestinationGroup `mapstructure:"destination_groups" yaml:"destination_groups,omitempty"`
+
+	// Redirect, or Response, replace To on routes that reply to requests
+	// themselves instead of proxying them upstream.
+	Redirect *PolicyRedirect `mapstructure:"redirect" yaml:"redirect,omitempty"`
+	Response *PolicyResponse `mapstructure:"response" yaml:"response,omitempty"`
+
 	// Identity related policy
 	AllowedEmails  []string `mapstructure:"allowed_users" yaml:"allowed_users,omitempty"`
 	AllowedGroups  []string `mapstructure:"allowed_groups" yaml:"allowed_groups,omitempty"`
@@ -23,6 +37,19 @@ type Policy struct {
 	Source      *url.URL `yaml:",omitempty"`
 	Destination *url.URL `yaml:",omitempty"`
 
+	// Prefix restricts the route to requests whose path begins with the given
+	// value, on a path segment boundary. Routes sharing the same hostname are
+	// matched longest prefix first, and each is authorized separately.
+	Prefix string `mapstructure:"prefix" yaml:"prefix,omitempty"`
+
+	// PrefixRewrite replaces the matched Prefix (or "/" if unset) with the
+	// given value before the request is sent upstream.
+	PrefixRewrite string `mapstructure:"prefix_rewrite" yaml:"prefix_rewrite,omitempty"`
+
+	// RegexRewrite rewrites the request path using a regular expression
+	// before the request is sent upstream.
+	RegexRewrite RegexRewrite `mapstructure:"regex_rewrite" yaml:"regex_rewrite,omitempty"`
+
 	// Allow unauthenticated HTTP OPTIONS requests as per the CORS spec
 	// https://developer.mozilla.org/en-US/docs/Web/HTTP/CORS#Preflighted_requests
 	CORSAllowPreflight bool `mapstructure:"cors_allow_preflight" yaml:"cors_allow_preflight,omitempty"`
@@ -34,10 +61,71 @@ type Policy struct {
 	// timeout. If unset,  route will fallback to the proxy's DefaultUpstreamTimeout.
 	UpstreamTimeout time.Duration `mapstructure:"timeout" yaml:"timeout,omitempty"`
 
+	// MaxRequestBodyBytes is the route specific limit on the size of request
+	// bodies. If unset, route will fallback to the DefaultMaxRequestBodyBytes.
+	MaxRequestBodyBytes int64 `mapstructure:"max_request_body_bytes" yaml:"max_request_body_bytes,omitempty"`
+
+	// RetryPolicy configures retrying failed, idempotent, requests to the
+	// upstream.
+	RetryPolicy RetryPolicy `mapstructure:"retry_policy" yaml:"retry_policy,omitempty"`
+
+	// Compression compresses upstream responses for clients that accept it.
+	Compression Compression `mapstructure:"compression" yaml:"compression,omitempty"`
+
+	// Cache stores cacheable upstream responses in memory.
+	Cache Cache `mapstructure:"cache" yaml:"cache,omitempty"`
+
+	// CircuitBreaker fails requests to the upstream fast, once it has been
+	// deemed unhealthy, until a cooldown has passed.
+	CircuitBreaker CircuitBreaker `mapstructure:"circuit_breaker" yaml:"circuit_breaker,omitempty"`
+
+	// Mirror sends a copy of a sample of the route's requests to a shadow
+	// upstream, whose responses are discarded.
+	Mirror Mirror `mapstructure:"mirror" yaml:"mirror,omitempty"`
+
 	// Enable proxying of websocket connections by removing the default timeout handler.
 	// Caution: Enabling this feature could result in abuse via DOS attacks.
 	AllowWebsockets bool `mapstructure:"allow_websockets"  yaml:"allow_websockets,omitempty"`
 
+	// IdleTimeout closes a long-lived connection, such as a websocket, TCP
+	// tunnel or gRPC stream, once no data has been sent in either direction
+	// for this long. If unset, idle connections are not closed.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout" yaml:"idle_timeout,omitempty"`
+
+	// MaxConnectionDuration closes a long-lived connection once it has been
+	// open for this long, regardless of activity. If unset, connections are
+	// not limited.
+	MaxConnectionDuration time.Duration `mapstructure:"max_connection_duration" yaml:"max_connection_duration,omitempty"`
+
+	// ReauthorizeInterval is how often a long-lived connection's session is
+	// checked, and its user re-authorized. The connection is closed once the
+	// session expires, or the user is no longer authorized. If unset, the
+	// DefaultReauthorizeInterval is used.
+	ReauthorizeInterval time.Duration `mapstructure:"reauthorize_interval" yaml:"reauthorize_interval,omitempty"`
+
+	// ForwardIDPToken sends the user's identity provider issued access token,
+	// or id token, to the upstream as the request's bearer token. Tokens are
+	// refreshed, by the authenticate service, as they expire.
+	ForwardIDPToken string `mapstructure:"forward_idp_token" yaml:"forward_idp_token,omitempty"`
+
+	// KubernetesServiceAccountToken is sent to a Kubernetes API server
+	// upstream as the request's bearer token, along with impersonation
+	// headers for the user making the request.
+	KubernetesServiceAccountToken string `mapstructure:"kubernetes_service_account_token" yaml:"kubernetes_service_account_token,omitempty"`
+
+	// TemplatesDir is a directory of html templates that override the error,
+	// forbidden and sign in pages of the route, and those of the global
+	// TemplatesDir.
+	TemplatesDir string `mapstructure:"templates_dir" yaml:"templates_dir,omitempty"`
+
+	// PreserveHostHeader passes the host header from the incoming request to
+	// the downstream request, instead of the destination's hostname.
+	PreserveHostHeader bool `mapstructure:"preserve_host_header" yaml:"preserve_host_header,omitempty"`
+
+	// HostRewrite sets the host header of the downstream request to a custom
+	// value, instead of the destination's hostname.
+	HostRewrite string `mapstructure:"host_rewrite" yaml:"host_rewrite,omitempty"`
+
 	// TLSSkipVerify controls whether a client verifies the server's certificate
 	// chain and host name.
 	// If TLSSkipVerify is true, TLS accepts any certificate presented by the
@@ -70,19 +158,519 @@ type Policy struct {
 	// in the form of key value pairs. Note bene, this will overwrite the
 	// value of any existing value of a given header key.
 	SetRequestHeaders map[string]string `mapstructure:"set_request_headers" yaml:"set_request_headers,omitempty"`
+
+	// RemoveRequestHeaders removes a collection of headers from the
+	// downstream request before it is sent.
+	RemoveRequestHeaders []string `mapstructure:"remove_request_headers" yaml:"remove_request_headers,omitempty"`
+
+	// SetResponseHeaders adds a collection of headers to the downstream's
+	// response in the form of key value pairs. Note bene, this will overwrite
+	// the value of any existing value of a given header key.
+	SetResponseHeaders map[string]string `mapstructure:"set_response_headers" yaml:"set_response_headers,omitempty"`
+
+	// RemoveResponseHeaders removes a collection of headers from the
+	// downstream's response.
+	RemoveResponseHeaders []string `mapstructure:"remove_response_headers" yaml:"remove_response_headers,omitempty"`
+}
+
+// DestinationGroup is one of a route's weighted destinations. Each user is
+// assigned to a group, in proportion to its weight, and stays in it for as
+// long as the route's groups are unchanged.
+type DestinationGroup struct {
+	// Name identifies the group, and labels its metrics.
+	Name        string   `mapstructure:"name" yaml:"name"`
+	To          string   `mapstructure:"to" yaml:"to"`
+	Destination *url.URL `yaml:",omitempty"`
+	// Weight is the group's share of users, relative to the route's other
+	// groups.
+	Weight int `mapstructure:"weight" yaml:"weight"`
+}
+
+// validateDestinationGroups parses the destinations of a policy's groups.
+// The policy's Destination is set to that of the first group.
+func (p *Policy) validateDestinationGroups() error {
+	names := make(map[string]bool, len(p.DestinationGroups))
+	total := 0
+	for i := range p.DestinationGroups {
+		g := &p.DestinationGroups[i]
+		if g.Name == "" {
+			return fmt.Errorf("config: policy destination groups must have a name")
+		}
+		if names[g.Name] {
+			return fmt.Errorf("config: policy has more than one destination group named %q", g.Name)
+		}
+		names[g.Name] = true
+		if g.Weight < 0 {
+			return fmt.Errorf("config: policy destination group %q weight cannot be negative", g.Name)
+		}
+		total += g.Weight
+		var err error
+		g.Destination, err = parseDestinationURL(g.To)
+		if err != nil {
+			return fmt.Errorf("config: policy destination group %q bad destination url %w", g.Name, err)
+		}
+		if g.Destination.Scheme == "tcp" {
+			return fmt.Errorf("config: policy destination group %q cannot be a tcp url", g.Name)
+		}
+		if g.Destination.Scheme != p.DestinationGroups[0].Destination.Scheme {
+			return fmt.Errorf("config: policy destination groups must all have the same scheme")
+		}
+	}
+	if total == 0 {
+		return fmt.Errorf("config: policy destination groups must have a weight")
+	}
+	p.Destination = p.DestinationGroups[0].Destination
+	return nil
+}
+
+// PolicyRedirect replies to a route's requests with a redirect.
+type PolicyRedirect struct {
+	// Status is the redirect's status code. Defaults to 302 Found.
+	Status int `mapstructure:"status" yaml:"status,omitempty"`
+	// To is the url requests are redirected to.
+	To string `mapstructure:"to" yaml:"to"`
+	// PreservePath appends the request's path, and query, to the redirect url.
+	PreservePath bool `mapstructure:"preserve_path" yaml:"preserve_path,omitempty"`
+
+	Destination *url.URL `yaml:"-"`
+}
+
+// Validate checks the validity of a redirect, and sets any defaults.
+func (pr *PolicyRedirect) Validate() error {
+	var err error
+	pr.Destination, err = urlutil.ParseAndValidateURL(pr.To)
+	if err != nil {
+		return fmt.Errorf("config: policy bad redirect url %w", err)
+	}
+	switch pr.Status {
+	case 0:
+		pr.Status = http.StatusFound
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther, http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+	default:
+		return fmt.Errorf("config: policy redirect status %d is not a redirect", pr.Status)
+	}
+	return nil
+}
+
+// PolicyResponse replies to a route's requests with a static response, e.g.
+// a maintenance page.
+type PolicyResponse struct {
+	// Status is the response's status code. Defaults to 200 OK.
+	Status  int               `mapstructure:"status" yaml:"status,omitempty"`
+	Body    string            `mapstructure:"body" yaml:"body,omitempty"`
+	Headers map[string]string `mapstructure:"headers" yaml:"headers,omitempty"`
+}
+
+// Validate checks the validity of a static response, and sets any defaults.
+func (pr *PolicyResponse) Validate() error {
+	if pr.Status == 0 {
+		pr.Status = http.StatusOK
+	}
+	if pr.Status < 200 || pr.Status > 599 {
+		return fmt.Errorf("config: policy response status %d is invalid", pr.Status)
+	}
+	return nil
+}
+
+// RegexRewrite contains a regular expression, and its substitution, used to
+// rewrite the path of a request before it is sent upstream.
+type RegexRewrite struct {
+	Pattern      string         `mapstructure:"pattern" yaml:"pattern,omitempty"`
+	Substitution string         `mapstructure:"substitution" yaml:"substitution,omitempty"`
+	Regexp       *regexp.Regexp `yaml:"-"`
+}
+
+// RetryPolicy configures how failed requests to an upstream are retried.
+// Only idempotent requests are retried. Connection failures are always
+// retried, as are any attempts that exceed the per-try timeout.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made, including the first.
+	// Retries are disabled unless greater than one.
+	MaxAttempts int `mapstructure:"max_attempts" yaml:"max_attempts,omitempty"`
+
+	// PerTryTimeout is the timeout for each attempt. If unset, attempts
+	// are only bound by the route's timeout.
+	PerTryTimeout time.Duration `mapstructure:"per_try_timeout" yaml:"per_try_timeout,omitempty"`
+
+	// BackoffBaseInterval and BackoffMaxInterval bound the exponential
+	// backoff, with full jitter, between attempts. The max interval defaults
+	// to ten times the base interval.
+	BackoffBaseInterval time.Duration `mapstructure:"backoff_base_interval" yaml:"backoff_base_interval,omitempty"`
+	BackoffMaxInterval  time.Duration `mapstructure:"backoff_max_interval" yaml:"backoff_max_interval,omitempty"`
+
+	// RetriableStatusCodes are upstream response status codes that will be
+	// retried (e.g. 502, 503).
+	RetriableStatusCodes []int `mapstructure:"retriable_status_codes" yaml:"retriable_status_codes,omitempty"`
+}
+
+// DefaultRetryBackoffBaseInterval is the base backoff interval used if a
+// retry policy does not specify its own.
+const DefaultRetryBackoffBaseInterval = 25 * time.Millisecond
+
+// Enabled returns true if failed requests should be retried.
+func (rp *RetryPolicy) Enabled() bool {
+	return rp.MaxAttempts > 1
+}
+
+// Validate checks the validity of a retry policy, and sets any defaults.
+func (rp *RetryPolicy) Validate() error {
+	if rp.MaxAttempts < 0 || rp.PerTryTimeout < 0 || rp.BackoffBaseInterval < 0 || rp.BackoffMaxInterval < 0 {
+		return fmt.Errorf("config: retry policy settings cannot be negative")
+	}
+	if !rp.Enabled() {
+		return nil
+	}
+	if rp.BackoffBaseInterval == 0 {
+		rp.BackoffBaseInterval = DefaultRetryBackoffBaseInterval
+	}
+	if rp.BackoffMaxInterval == 0 {
+		rp.BackoffMaxInterval = 10 * rp.BackoffBaseInterval
+	}
+	if rp.BackoffMaxInterval < rp.BackoffBaseInterval {
+		return fmt.Errorf("config: retry policy max backoff interval must not be less than the base interval")
+	}
+	for _, code := range rp.RetriableStatusCodes {
+		if code < 100 || code > 599 {
+			return fmt.Errorf("config: retry policy has invalid status code %d", code)
+		}
+	}
+	return nil
+}
+
+// Compression configures compressing a route's responses, for clients that
+// accept it, when the upstream has not already done so.
+type Compression struct {
+	Enabled bool `mapstructure:"enabled" yaml:"enabled,omitempty"`
+
+	// Encodings are the content encodings that may be used, in order of
+	// preference. Supported encodings are `br` and `gzip`.
+	Encodings []string `mapstructure:"encodings" yaml:"encodings,omitempty"`
+
+	// ContentTypes are the media types that are compressed. A subtype of `*`
+	// matches any subtype, e.g. `text/*`.
+	ContentTypes []string `mapstructure:"content_types" yaml:"content_types,omitempty"`
+
+	// MinSizeBytes is the size of the smallest response that is compressed.
+	MinSizeBytes int `mapstructure:"min_size_bytes" yaml:"min_size_bytes,omitempty"`
+}
+
+// Default compression settings, used if a policy's compression does not
+// specify its own.
+var (
+	DefaultCompressionEncodings    = []string{"br", "gzip"}
+	DefaultCompressionContentTypes = []string{
+		"application/javascript",
+		"application/json",
+		"application/xml",
+		"image/svg+xml",
+		"text/css",
+		"text/html",
+		"text/javascript",
+		"text/plain",
+		"text/xml",
+	}
+	DefaultCompressionMinSizeBytes = 1024
+)
+
+// Validate checks the validity of compression settings, and sets any defaults.
+func (c *Compression) Validate() error {
+	if c.MinSizeBytes < 0 {
+		return fmt.Errorf("config: compression min size cannot be negative")
+	}
+	for _, encoding := range c.Encodings {
+		if encoding != "br" && encoding != "gzip" {
+			return fmt.Errorf("config: compression encoding %q is not supported", encoding)
+		}
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.Encodings) == 0 {
+		c.Encodings = DefaultCompressionEncodings
+	}
+	if len(c.ContentTypes) == 0 {
+		c.ContentTypes = DefaultCompressionContentTypes
+	}
+	if c.MinSizeBytes == 0 {
+		c.MinSizeBytes = DefaultCompressionMinSizeBytes
+	}
+	return nil
+}
+
+// Cache configures an in-memory cache of a route's upstream responses.
+// Responses are only stored if their Cache-Control, or Expires, headers make
+// them explicitly cacheable, and they are not shared across users unless
+// marked public.
+type Cache struct {
+	// MaxSizeBytes is the total size of the responses the cache can hold.
+	MaxSizeBytes int64 `mapstructure:"max_size_bytes" yaml:"max_size_bytes,omitempty"`
+
+	// MaxEntryBytes is the size of the largest response the cache will store.
+	MaxEntryBytes int64 `mapstructure:"max_entry_bytes" yaml:"max_entry_bytes,omitempty"`
+}
+
+// DefaultCacheMaxEntryBytes is the default size of the largest response a
+// route's cache will store.
+const DefaultCacheMaxEntryBytes = 1 << 20
+
+// Enabled returns true if the cache has a size.
+func (c *Cache) Enabled() bool {
+	return c.MaxSizeBytes > 0
+}
+
+// Validate checks the validity of a cache, and sets any defaults.
+func (c *Cache) Validate() error {
+	if c.MaxSizeBytes < 0 || c.MaxEntryBytes < 0 {
+		return fmt.Errorf("config: cache sizes cannot be negative")
+	}
+	if !c.Enabled() {
+		return nil
+	}
+	if c.MaxEntryBytes == 0 {
+		c.MaxEntryBytes = DefaultCacheMaxEntryBytes
+	}
+	if c.MaxEntryBytes > c.MaxSizeBytes {
+		c.MaxEntryBytes = c.MaxSizeBytes
+	}
+	return nil
+}
+
+// CircuitBreaker configures when requests to an upstream should fail fast.
+// The breaker opens once the upstream's error rate, or the number of
+// concurrent requests to it, reaches a threshold. After a cooldown, a single
+// trial request is let through (half-open) to decide whether the breaker
+// closes again.
+type CircuitBreaker struct {
+	// ErrorRateThreshold is the fraction of failed requests, between 0 and 1,
+	// within an interval that opens the breaker. Transport errors and 5xx
+	// responses are considered failures.
+	ErrorRateThreshold float64 `mapstructure:"error_rate_threshold" yaml:"error_rate_threshold,omitempty"`
+
+	// MinRequests is the minimum number of requests within an interval
+	// before the error rate is considered.
+	MinRequests int `mapstructure:"min_requests" yaml:"min_requests,omitempty"`
+
+	// Interval is the period over which the error rate is measured.
+	Interval time.Duration `mapstructure:"interval" yaml:"interval,omitempty"`
+
+	// MaxConcurrentRequests is the number of concurrent requests that
+	// opens the breaker.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests" yaml:"max_concurrent_requests,omitempty"`
+
+	// Cooldown is how long the breaker stays open before half-opening.
+	Cooldown time.Duration `mapstructure:"cooldown" yaml:"cooldown,omitempty"`
+}
+
+// Default circuit breaker settings, used if a policy's circuit breaker does
+// not specify its own.
+const (
+	DefaultCircuitBreakerMinRequests = 10
+	DefaultCircuitBreakerInterval    = 10 * time.Second
+	DefaultCircuitBreakerCooldown    = 5 * time.Second
+)
+
+// Enabled returns true if the circuit breaker has a threshold set.
+func (cb *CircuitBreaker) Enabled() bool {
+	return cb.ErrorRateThreshold > 0 || cb.MaxConcurrentRequests > 0
+}
+
+// Validate checks the validity of a circuit breaker, and sets any defaults.
+func (cb *CircuitBreaker) Validate() error {
+	if cb.ErrorRateThreshold < 0 || cb.ErrorRateThreshold > 1 {
+		return fmt.Errorf("config: circuit breaker error rate threshold must be between 0 and 1")
+	}
+	if cb.MinRequests < 0 || cb.Interval < 0 || cb.MaxConcurrentRequests < 0 || cb.Cooldown < 0 {
+		return fmt.Errorf("config: circuit breaker settings cannot be negative")
+	}
+	if !cb.Enabled() {
+		return nil
+	}
+	if cb.MinRequests == 0 {
+		cb.MinRequests = DefaultCircuitBreakerMinRequests
+	}
+	if cb.Interval == 0 {
+		cb.Interval = DefaultCircuitBreakerInterval
+	}
+	if cb.Cooldown == 0 {
+		cb.Cooldown = DefaultCircuitBreakerCooldown
+	}
+	return nil
+}
+
+// Mirror configures copying a route's requests to a shadow upstream. Mirrored
+// requests are sent in the background, once the request has been sent to the
+// route's own upstream, and never affect its response.
+type Mirror struct {
+	// To is the url of the shadow upstream.
+	To          string   `mapstructure:"to" yaml:"to,omitempty"`
+	Destination *url.URL `yaml:",omitempty"`
+
+	// Percent is the percentage, between 0 and 100, of requests mirrored.
+	// Unlike the other settings, 0 is not the default but mirrors nothing.
+	Percent *float64 `mapstructure:"percent" yaml:"percent,omitempty"`
+
+	// MaxConcurrentRequests is the number of mirrored requests that can be
+	// in flight at once. Requests beyond it are not mirrored.
+	MaxConcurrentRequests int `mapstructure:"max_concurrent_requests" yaml:"max_concurrent_requests,omitempty"`
+
+	// MaxBodyBytes is the size of the largest request body that is mirrored.
+	MaxBodyBytes int64 `mapstructure:"max_body_bytes" yaml:"max_body_bytes,omitempty"`
+
+	// Timeout is how long a mirrored request can take.
+	Timeout time.Duration `mapstructure:"timeout" yaml:"timeout,omitempty"`
+}
+
+// Default mirror settings, used if a policy's mirror does not specify its own.
+const (
+	DefaultMirrorPercent               = 100
+	DefaultMirrorMaxConcurrentRequests = 100
+	DefaultMirrorMaxBodyBytes          = 1 << 20
+	DefaultMirrorTimeout               = 30 * time.Second
+)
+
+// Enabled returns true if the mirror has a destination.
+func (m *Mirror) Enabled() bool {
+	return m.To != ""
+}
+
+// Validate checks the validity of a mirror, and sets any defaults.
+func (m *Mirror) Validate() error {
+	if !m.Enabled() {
+		return nil
+	}
+	var err error
+	m.Destination, err = urlutil.ParseAndValidateURL(m.To)
+	if err != nil {
+		return fmt.Errorf("config: policy bad mirror url %w", err)
+	}
+	if m.Destination.Scheme != "http" && m.Destination.Scheme != "https" {
+		return fmt.Errorf("config: policy mirror url must be http or https")
+	}
+	if m.Percent != nil && (*m.Percent < 0 || *m.Percent > 100) {
+		return fmt.Errorf("config: policy mirror percent must be between 0 and 100")
+	}
+	if m.MaxConcurrentRequests < 0 || m.MaxBodyBytes < 0 || m.Timeout < 0 {
+		return fmt.Errorf("config: policy mirror settings cannot be negative")
+	}
+	if m.Percent == nil {
+		percent := float64(DefaultMirrorPercent)
+		m.Percent = &percent
+	}
+	if m.MaxConcurrentRequests == 0 {
+		m.MaxConcurrentRequests = DefaultMirrorMaxConcurrentRequests
+	}
+	if m.MaxBodyBytes == 0 {
+		m.MaxBodyBytes = DefaultMirrorMaxBodyBytes
+	}
+	if m.Timeout == 0 {
+		m.Timeout = DefaultMirrorTimeout
+	}
+	return nil
+}
+
+// Identity provider tokens a policy can forward to its upstream.
+const (
+	ForwardAccessToken = "access_token"
+	ForwardIDToken     = "id_token"
+)
+
+// DefaultReauthorizeInterval is how often long-lived connections are
+// re-authorized if a policy does not set its own interval.
+const DefaultReauthorizeInterval = time.Minute
+
+// TCPSchemePrefix prefixes the source url of routes that tunnel TCP
+// connections, e.g. `tcp+https://redis.corp.example.com:6379`.
+const TCPSchemePrefix = "tcp+"
+
+// IsTCP returns true if the route tunnels TCP connections, made with HTTP
+// CONNECT requests, to a `tcp://` destination rather than proxying HTTP.
+func (p *Policy) IsTCP() bool {
+	return strings.HasPrefix(p.From, TCPSchemePrefix)
+}
+
+// IsH2C returns true if the route proxies to its destination using
+// cleartext HTTP/2, as with `grpc://` and `h2c://` destinations.
+func (p *Policy) IsH2C() bool {
+	return p.Destination != nil && (p.Destination.Scheme == "grpc" || p.Destination.Scheme == "h2c")
+}
+
+// IsUnix returns true if the route proxies to a unix domain socket, e.g.
+// `unix:///var/run/app.sock`.
+func (p *Policy) IsUnix() bool {
+	return p.Destination != nil && p.Destination.Scheme == "unix"
+}
+
+// IsStatic returns true if the route replies to requests with a redirect or
+// static response, instead of proxying them to a destination.
+func (p *Policy) IsStatic() bool {
+	return p.Redirect != nil || p.Response != nil
+}
+
+// RouteID returns the id a route is authorized by: its source host, followed
+// by its path prefix, if any.
+func (p *Policy) RouteID() string {
+	return p.Source.Host + p.Prefix
+}
+
+// MatchesPath returns true if path is within the route's prefix. A prefix
+// only matches whole path segments, so `/grafana` matches `/grafana` and
+// `/grafana/login`, but not `/grafanafoo`.
+func (p *Policy) MatchesPath(path string) bool {
+	prefix := p.Prefix
+	if prefix == "" {
+		prefix = "/"
+	}
+	if strings.HasSuffix(prefix, "/") {
+		return strings.HasPrefix(path, prefix)
+	}
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
 }
 
 // Validate checks the validity of a policy.
 func (p *Policy) Validate() error {
 	var err error
-	p.Source, err = urlutil.ParseAndValidateURL(p.From)
+	p.Source, err = urlutil.ParseAndValidateURL(strings.TrimPrefix(p.From, TCPSchemePrefix))
 	if err != nil {
 		return fmt.Errorf("config: policy bad source url %w", err)
 	}
 
-	p.Destination, err = urlutil.ParseAndValidateURL(p.To)
-	if err != nil {
-		return fmt.Errorf("config: policy bad destination url %w", err)
+	switch {
+	case p.Redirect != nil && p.Response != nil, p.IsStatic() && p.To != "":
+		return fmt.Errorf("config: policy must only have one of to, redirect or response")
+	case p.IsStatic() && p.IsTCP():
+		return fmt.Errorf("config: tcp policy cannot have a redirect or response")
+	case len(p.DestinationGroups) != 0 && (p.IsStatic() || p.To != ""):
+		return fmt.Errorf("config: policy must only have one of to, destination groups, redirect or response")
+	case len(p.DestinationGroups) != 0 && p.IsTCP():
+		return fmt.Errorf("config: tcp policy cannot have destination groups")
+	case len(p.DestinationGroups) != 0:
+		if err := p.validateDestinationGroups(); err != nil {
+			return err
+		}
+	case p.Redirect != nil:
+		if err := p.Redirect.Validate(); err != nil {
+			return err
+		}
+	case p.Response != nil:
+		if err := p.Response.Validate(); err != nil {
+			return err
+		}
+	default:
+		p.Destination, err = parseDestinationURL(p.To)
+		if err != nil {
+			return fmt.Errorf("config: policy bad destination url %w", err)
+		}
+	}
+
+	if p.IsTCP() {
+		if p.Source.Port() == "" || p.Destination.Port() == "" {
+			return fmt.Errorf("config: tcp policy source and destination must include a port")
+		}
+		if p.Destination.Scheme != "tcp" {
+			return fmt.Errorf("config: tcp policy destination must be a tcp url")
+		}
+	} else if p.Destination != nil && p.Destination.Scheme == "tcp" {
+		return fmt.Errorf("config: tcp policy source must begin with %q", TCPSchemePrefix)
 	}
 
 	// Only allow public access if no other whitelists are in place
@@ -90,6 +678,90 @@ func (p *Policy) Validate() error {
 		return fmt.Errorf("config: policy route marked as public but contains whitelists")
 	}
 
+	if p.Prefix != "" && !strings.HasPrefix(p.Prefix, "/") {
+		return fmt.Errorf("config: policy prefix must begin with '/'")
+	}
+
+	if p.PrefixRewrite != "" && !strings.HasPrefix(p.PrefixRewrite, "/") {
+		return fmt.Errorf("config: policy prefix rewrite must begin with '/'")
+	}
+
+	if p.PrefixRewrite != "" && p.RegexRewrite.Pattern != "" {
+		return fmt.Errorf("config: policy cannot have both a prefix and regex rewrite")
+	}
+
+	if p.RegexRewrite.Pattern != "" {
+		p.RegexRewrite.Regexp, err = regexp.Compile(p.RegexRewrite.Pattern)
+		if err != nil {
+			return fmt.Errorf("config: policy bad regex rewrite pattern %w", err)
+		}
+	}
+
+	if p.MaxRequestBodyBytes < 0 {
+		return fmt.Errorf("config: max request body bytes cannot be negative")
+	}
+
+	if p.IdleTimeout < 0 || p.MaxConnectionDuration < 0 || p.ReauthorizeInterval < 0 {
+		return fmt.Errorf("config: policy connection timeouts cannot be negative")
+	}
+
+	switch p.ForwardIDPToken {
+	case "", ForwardAccessToken, ForwardIDToken:
+	default:
+		return fmt.Errorf("config: policy forward idp token %q is not one of %q or %q", p.ForwardIDPToken, ForwardAccessToken, ForwardIDToken)
+	}
+	if p.ForwardIDPToken != "" && (p.AllowPublicUnauthenticatedAccess || p.IsTCP() || p.IsStatic()) {
+		return fmt.Errorf("config: policy can only forward idp tokens to authenticated http upstreams")
+	}
+
+	if p.KubernetesServiceAccountToken != "" {
+		if p.AllowPublicUnauthenticatedAccess || p.IsTCP() || p.IsStatic() {
+			return fmt.Errorf("config: policy can only impersonate users of authenticated http upstreams")
+		}
+		if p.ForwardIDPToken != "" {
+			return fmt.Errorf("config: policy cannot both forward idp tokens and a kubernetes service account token")
+		}
+	}
+
+	if err := p.RetryPolicy.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.CircuitBreaker.Validate(); err != nil {
+		return err
+	}
+
+	if p.Mirror.Enabled() && (p.IsTCP() || p.IsStatic()) {
+		return fmt.Errorf("config: policy can only mirror requests to http upstreams")
+	}
+	// the shadow upstream must not be handed credentials for the route's own
+	if p.Mirror.Enabled() && (p.ForwardIDPToken != "" || p.KubernetesServiceAccountToken != "") {
+		return fmt.Errorf("config: policy cannot both mirror requests and forward idp or kubernetes service account tokens")
+	}
+	if err := p.Mirror.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.Cache.Validate(); err != nil {
+		return err
+	}
+
+	if err := p.Compression.Validate(); err != nil {
+		return err
+	}
+
+	if p.PreserveHostHeader && p.HostRewrite != "" {
+		return fmt.Errorf("config: policy cannot both preserve and rewrite the host header")
+	}
+
+	if key := overlappingHeader(p.SetRequestHeaders, p.RemoveRequestHeaders); key != "" {
+		return fmt.Errorf("config: policy cannot both set and remove request header %s", key)
+	}
+
+	if key := overlappingHeader(p.SetResponseHeaders, p.RemoveResponseHeaders); key != "" {
+		return fmt.Errorf("config: policy cannot both set and remove response header %s", key)
+	}
+
 	if (p.TLSClientCert == "" && p.TLSClientKey != "") || (p.TLSClientCert != "" && p.TLSClientKey == "") ||
 		(p.TLSClientCertFile == "" && p.TLSClientKeyFile != "") || (p.TLSClientCertFile != "" && p.TLSClientKeyFile == "") {
 		return fmt.Errorf("config: client certificate key and cert both must be non-empty")
@@ -121,8 +793,43 @@ func (p *Policy) Validate() error {
 
 	return nil
 }
+
+// parseDestinationURL parses a policy's destination. Unix socket urls have
+// no host, only the absolute path to the socket.
+func parseDestinationURL(rawurl string) (*url.URL, error) {
+	if !strings.HasPrefix(rawurl, "unix:") {
+		return urlutil.ParseAndValidateURL(rawurl)
+	}
+	u, err := url.Parse(rawurl)
+	if err != nil {
+		return nil, err
+	}
+	if u.Host != "" || !strings.HasPrefix(u.Path, "/") {
+		return nil, fmt.Errorf("%s url must be of the form unix:///path/to.sock", rawurl)
+	}
+	return u, nil
+}
+
+// overlappingHeader returns the first header key to be removed that is also
+// set, if any. Header keys are case-insensitive.
+func overlappingHeader(set map[string]string, remove []string) string {
+	for _, key := range remove {
+		for setKey := range set {
+			if strings.EqualFold(key, setKey) {
+				return key
+			}
+		}
+	}
+	return ""
+}
+
 func (p *Policy) String() string {
-	if p.Source == nil || p.Destination == nil {
+	switch {
+	case p.Redirect != nil:
+		return fmt.Sprintf("%s → redirect %s", p.From, p.Redirect.To)
+	case p.Response != nil:
+		return fmt.Sprintf("%s → response %d", p.From, p.Response.Status)
+	case p.Source == nil || p.Destination == nil:
 		return fmt.Sprintf("%s → %s", p.From, p.To)
 	}
 	return fmt.Sprintf("%s → %s", p.Source.String(), p.Destination.String())
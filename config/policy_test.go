@@ -2,10 +2,12 @@ package config
 
 import (
 	"testing"
+	"time"
 )
 
 func Test_PolicyValidate(t *testing.T) {
 	t.Parallel()
+	mirrorPercent, mirrorNothing, badMirrorPercent := 10.0, 0.0, 110.0
 
 	tests := []struct {
 		name    string
@@ -33,6 +35,77 @@ func Test_PolicyValidate(t *testing.T) {
 		{"bad certificate file", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", TLSClientCertFile: "testdata/example-cert-404.pem", TLSClientKeyFile: "testdata/example-key.pem"}, true},
 		{"bad key file", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", TLSClientCertFile: "testdata/example-cert.pem", TLSClientKeyFile: "testdata/example-key-404.pem"}, true},
 		{"good tls server name", Policy{From: "https://httpbin.corp.example", To: "https://internal-host-name", TLSServerName: "httpbin.corp.notatld"}, false},
+		{"good prefix rewrite", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Prefix: "/grafana", PrefixRewrite: "/"}, false},
+		{"bad prefix", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Prefix: "grafana"}, true},
+		{"bad prefix rewrite", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", PrefixRewrite: "grafana"}, true},
+		{"good regex rewrite", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", RegexRewrite: RegexRewrite{Pattern: "^/grafana(/.*)$", Substitution: "$1"}}, false},
+		{"bad regex rewrite", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", RegexRewrite: RegexRewrite{Pattern: "(", Substitution: "$1"}}, true},
+		{"both prefix and regex rewrite", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", PrefixRewrite: "/", RegexRewrite: RegexRewrite{Pattern: "^/grafana", Substitution: ""}}, true},
+		{"good response headers", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", SetResponseHeaders: map[string]string{"X-Frame-Options": "DENY"}, RemoveResponseHeaders: []string{"Server"}}, false},
+		{"set and remove response header", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", SetResponseHeaders: map[string]string{"X-Frame-Options": "DENY"}, RemoveResponseHeaders: []string{"x-frame-options"}}, true},
+		{"set and remove request header", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", SetRequestHeaders: map[string]string{"Authorization": "Basic"}, RemoveRequestHeaders: []string{"Authorization"}}, true},
+		{"preserve host header", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", PreserveHostHeader: true}, false},
+		{"host rewrite", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", HostRewrite: "httpbin.internal"}, false},
+		{"preserve and rewrite host header", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", PreserveHostHeader: true, HostRewrite: "httpbin.internal"}, true},
+		{"good retry policy", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", RetryPolicy: RetryPolicy{MaxAttempts: 3, PerTryTimeout: time.Second, RetriableStatusCodes: []int{502, 503}}}, false},
+		{"negative retry attempts", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", RetryPolicy: RetryPolicy{MaxAttempts: -1}}, true},
+		{"bad retry backoff", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", RetryPolicy: RetryPolicy{MaxAttempts: 3, BackoffBaseInterval: time.Second, BackoffMaxInterval: time.Millisecond}}, true},
+		{"bad retry status code", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", RetryPolicy: RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{5000}}}, true},
+		{"good circuit breaker", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", CircuitBreaker: CircuitBreaker{ErrorRateThreshold: 0.5, MaxConcurrentRequests: 100, Cooldown: time.Second}}, false},
+		{"bad circuit breaker error rate", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", CircuitBreaker: CircuitBreaker{ErrorRateThreshold: 1.5}}, true},
+		{"negative circuit breaker cooldown", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", CircuitBreaker: CircuitBreaker{ErrorRateThreshold: 0.5, Cooldown: -time.Second}}, true},
+		{"good max request body bytes", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", MaxRequestBodyBytes: 1 << 20}, false},
+		{"negative max request body bytes", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", MaxRequestBodyBytes: -1}, true},
+		{"good tcp", Policy{From: "tcp+https://redis.corp.example:6379", To: "tcp://redis.corp.notatld:6379"}, false},
+		{"tcp missing source port", Policy{From: "tcp+https://redis.corp.example", To: "tcp://redis.corp.notatld:6379"}, true},
+		{"tcp missing destination port", Policy{From: "tcp+https://redis.corp.example:6379", To: "tcp://redis.corp.notatld"}, true},
+		{"tcp http destination", Policy{From: "tcp+https://redis.corp.example:6379", To: "https://redis.corp.notatld:6379"}, true},
+		{"good connection timeouts", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", AllowWebsockets: true, IdleTimeout: time.Minute, MaxConnectionDuration: time.Hour, ReauthorizeInterval: 30 * time.Second}, false},
+		{"negative idle timeout", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", IdleTimeout: -1}, true},
+		{"negative reauthorize interval", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", ReauthorizeInterval: -1}, true},
+		{"good unix socket", Policy{From: "https://httpbin.corp.example", To: "unix:///var/run/httpbin.sock"}, false},
+		{"unix socket with host", Policy{From: "https://httpbin.corp.example", To: "unix://httpbin.sock"}, true},
+		{"unix socket without path", Policy{From: "https://httpbin.corp.example", To: "unix://"}, true},
+		{"tcp unix socket destination", Policy{From: "tcp+https://redis.corp.example:6379", To: "unix:///var/run/redis.sock"}, true},
+		{"good grpc", Policy{From: "https://grpc.corp.example", To: "grpc://grpc.corp.notatld:50051"}, false},
+		{"good h2c", Policy{From: "https://grpc.corp.example", To: "h2c://grpc.corp.notatld:8080"}, false},
+		{"tcp grpc destination", Policy{From: "tcp+https://grpc.corp.example:50051", To: "grpc://grpc.corp.notatld:50051"}, true},
+		{"tcp destination without tcp source", Policy{From: "https://redis.corp.example:6379", To: "tcp://redis.corp.notatld:6379"}, true},
+		{"good redirect", Policy{From: "https://httpbin.corp.example", Redirect: &PolicyRedirect{To: "https://httpbin.corp.notatld", Status: 301}}, false},
+		{"redirect bad url", Policy{From: "https://httpbin.corp.example", Redirect: &PolicyRedirect{To: "httpbin"}}, true},
+		{"redirect bad status", Policy{From: "https://httpbin.corp.example", Redirect: &PolicyRedirect{To: "https://httpbin.corp.notatld", Status: 200}}, true},
+		{"redirect and to", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Redirect: &PolicyRedirect{To: "https://httpbin.corp.notatld"}}, true},
+		{"good response", Policy{From: "https://httpbin.corp.example", Response: &PolicyResponse{Status: 503, Body: "down for maintenance"}}, false},
+		{"response bad status", Policy{From: "https://httpbin.corp.example", Response: &PolicyResponse{Status: 42}}, true},
+		{"redirect and response", Policy{From: "https://httpbin.corp.example", Redirect: &PolicyRedirect{To: "https://httpbin.corp.notatld"}, Response: &PolicyResponse{}}, true},
+		{"tcp response", Policy{From: "tcp+https://redis.corp.example:6379", Response: &PolicyResponse{}}, true},
+		{"destination groups", Policy{From: "https://httpbin.corp.example", DestinationGroups: []DestinationGroup{{Name: "stable", To: "https://v1.corp.notatld", Weight: 95}, {Name: "canary", To: "https://v2.corp.notatld", Weight: 5}}}, false},
+		{"destination groups and to", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", DestinationGroups: []DestinationGroup{{Name: "stable", To: "https://v1.corp.notatld", Weight: 1}}}, true},
+		{"destination group without name", Policy{From: "https://httpbin.corp.example", DestinationGroups: []DestinationGroup{{To: "https://v1.corp.notatld", Weight: 1}}}, true},
+		{"duplicate destination group", Policy{From: "https://httpbin.corp.example", DestinationGroups: []DestinationGroup{{Name: "stable", To: "https://v1.corp.notatld", Weight: 1}, {Name: "stable", To: "https://v2.corp.notatld", Weight: 1}}}, true},
+		{"destination groups without weight", Policy{From: "https://httpbin.corp.example", DestinationGroups: []DestinationGroup{{Name: "stable", To: "https://v1.corp.notatld"}}}, true},
+		{"destination groups mixed schemes", Policy{From: "https://httpbin.corp.example", DestinationGroups: []DestinationGroup{{Name: "stable", To: "https://v1.corp.notatld", Weight: 1}, {Name: "canary", To: "h2c://v2.corp.notatld", Weight: 1}}}, true},
+		{"tcp destination groups", Policy{From: "tcp+https://redis.corp.example:6379", DestinationGroups: []DestinationGroup{{Name: "stable", To: "tcp://redis.corp.notatld:6379", Weight: 1}}}, true},
+		{"good mirror", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Mirror: Mirror{To: "https://shadow.corp.notatld", Percent: &mirrorPercent}}, false},
+		{"mirror nothing", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Mirror: Mirror{To: "https://shadow.corp.notatld", Percent: &mirrorNothing}}, false},
+		{"bad mirror url", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Mirror: Mirror{To: "shadow"}}, true},
+		{"bad mirror percent", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Mirror: Mirror{To: "https://shadow.corp.notatld", Percent: &badMirrorPercent}}, true},
+		{"mirror idp tokens", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", ForwardIDPToken: ForwardAccessToken, Mirror: Mirror{To: "https://shadow.corp.notatld"}}, true},
+		{"mirror kubernetes", Policy{From: "https://k8s.corp.example", To: "https://kubernetes.corp.notatld", KubernetesServiceAccountToken: "token", Mirror: Mirror{To: "https://shadow.corp.notatld"}}, true},
+		{"mirror static route", Policy{From: "https://httpbin.corp.example", Response: &PolicyResponse{Body: "ok"}, Mirror: Mirror{To: "https://shadow.corp.notatld"}}, true},
+		{"good cache", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Cache: Cache{MaxSizeBytes: 1 << 20}}, false},
+		{"negative cache size", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Cache: Cache{MaxSizeBytes: -1}}, true},
+		{"good compression", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Compression: Compression{Enabled: true, Encodings: []string{"gzip"}}}, false},
+		{"bad compression encoding", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Compression: Compression{Enabled: true, Encodings: []string{"deflate"}}}, true},
+		{"negative compression min size", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", Compression: Compression{Enabled: true, MinSizeBytes: -1}}, true},
+		{"forward access token", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", ForwardIDPToken: "access_token"}, false},
+		{"forward id token", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", ForwardIDPToken: "id_token"}, false},
+		{"forward unknown token", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", ForwardIDPToken: "refresh_token"}, true},
+		{"forward token to public route", Policy{From: "https://httpbin.corp.example", To: "https://httpbin.corp.notatld", ForwardIDPToken: "access_token", AllowPublicUnauthenticatedAccess: true}, true},
+		{"kubernetes", Policy{From: "https://k8s.corp.example", To: "https://kubernetes.default.svc", KubernetesServiceAccountToken: "token"}, false},
+		{"kubernetes public route", Policy{From: "https://k8s.corp.example", To: "https://kubernetes.default.svc", KubernetesServiceAccountToken: "token", AllowPublicUnauthenticatedAccess: true}, true},
+		{"kubernetes and forward token", Policy{From: "https://k8s.corp.example", To: "https://kubernetes.default.svc", KubernetesServiceAccountToken: "token", ForwardIDPToken: "id_token"}, true},
+		{"forward token to tcp route", Policy{From: "tcp+https://redis.corp.example:6379", To: "tcp://redis.internal:6379", ForwardIDPToken: "access_token"}, true},
 	}
 
 	for _, tt := range tests {
@@ -69,3 +142,27 @@ func TestPolicy_String(t *testing.T) {
 		})
 	}
 }
+
+func TestPolicy_MatchesPath(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		prefix string
+		path   string
+		want   bool
+	}{
+		{"", "/", true},
+		{"", "/anything", true},
+		{"/grafana", "/grafana", true},
+		{"/grafana", "/grafana/login", true},
+		{"/grafana", "/grafanafoo", false},
+		{"/grafana", "/", false},
+		{"/grafana/", "/grafana/login", true},
+		{"/grafana/", "/grafana", false},
+	}
+	for _, tt := range tests {
+		p := Policy{Prefix: tt.prefix}
+		if got := p.MatchesPath(tt.path); got != tt.want {
+			t.Errorf("MatchesPath(%q) with prefix %q = %v, want %v", tt.path, tt.prefix, got, tt.want)
+		}
+	}
+}
@@ -112,6 +112,7 @@ module.exports = {
           children: [
             "reference/certificates",
             "reference/impersonation",
+            "reference/sessions",
             "reference/programmatic-access",
             "reference/getting-users-identity",
             "reference/signed-headers",
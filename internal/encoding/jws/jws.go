@@ -3,7 +3,10 @@
 package jws // import "github.com/pomerium/pomerium/internal/encoding/jws"
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"encoding/base64"
+	"errors"
 
 	"github.com/pomerium/pomerium/internal/cryptutil"
 	"github.com/pomerium/pomerium/internal/encoding"
@@ -47,7 +50,12 @@ func NewES256Signer(privKey, issuer string) (*JSONWebSigner, error) {
 	if err != nil {
 		return nil, err
 	}
-	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: key},
+	// the key id lets verifiers pick the public key from a key set
+	kid, err := keyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.ES256, Key: jose.JSONWebKey{Key: key, KeyID: kid}},
 		(&jose.SignerOptions{}).WithType("JWT"))
 	if err != nil {
 		return nil, err
@@ -69,3 +77,46 @@ func (c *JSONWebSigner) Unmarshal(value []byte, s interface{}) error {
 	}
 	return tok.Claims(c.key, s)
 }
+
+// PublicJWKSet returns the JSON Web Key Set, as specified by rfc7517, of the
+// public part of a base64 encoded ES256 private key, for verifiers to fetch.
+// If the private key is empty, the key set is empty.
+func PublicJWKSet(privKey string) (*jose.JSONWebKeySet, error) {
+	jwks := &jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	if privKey == "" {
+		return jwks, nil
+	}
+	decodedSigningKey, err := base64.StdEncoding.DecodeString(privKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err := cryptutil.DecodePrivateKey(decodedSigningKey)
+	if err != nil {
+		return nil, err
+	}
+	kid, err := keyID(&key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	jwks.Keys = append(jwks.Keys, jose.JSONWebKey{
+		Key:       &key.PublicKey,
+		KeyID:     kid,
+		Algorithm: string(jose.ES256),
+		Use:       "sig",
+	})
+	return jwks, nil
+}
+
+// keyID returns the id of a public key, its base64url encoded SHA-256 JWK
+// thumbprint as specified by rfc7638, so that the same key always has the
+// same id.
+func keyID(key *ecdsa.PublicKey) (string, error) {
+	if key == nil {
+		return "", errors.New("jws: missing public key")
+	}
+	thumbprint, err := (&jose.JSONWebKey{Key: key}).Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(thumbprint), nil
+}
@@ -0,0 +1,99 @@
+package jws
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pomerium/pomerium/internal/cryptutil"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func newTestSigningKey(t *testing.T) string {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey, err := cryptutil.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(pemKey)
+}
+
+func TestPublicJWKSet(t *testing.T) {
+	t.Parallel()
+	signingKey := newTestSigningKey(t)
+	tests := []struct {
+		name     string
+		key      string
+		wantKeys int
+		wantErr  bool
+	}{
+		{"good", signingKey, 1, false},
+		{"no key", "", 0, false},
+		{"not base64", "^", 0, true},
+		{"bad key", base64.StdEncoding.EncodeToString([]byte("bad key")), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := PublicJWKSet(tt.key)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("PublicJWKSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if len(got.Keys) != tt.wantKeys {
+				t.Fatalf("PublicJWKSet() = %d keys, want %d", len(got.Keys), tt.wantKeys)
+			}
+			b, err := json.Marshal(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !strings.HasPrefix(string(b), `{"keys":[`) || strings.Contains(string(b), `"d":`) {
+				t.Errorf("PublicJWKSet() json = %s", b)
+			}
+		})
+	}
+}
+
+func TestES256Signer_keyID(t *testing.T) {
+	t.Parallel()
+	signingKey := newTestSigningKey(t)
+	signer, err := NewES256Signer(signingKey, "pomerium-proxy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	token, err := signer.Marshal(jwt.Claims{Subject: "user"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	jwks, err := PublicJWKSet(signingKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// verifiers find the public key in the key set by the token's key id
+	tok, err := jwt.ParseSigned(string(token))
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid := tok.Headers[0].KeyID
+	keys := jwks.Key(kid)
+	if kid == "" || len(keys) != 1 {
+		t.Fatalf("key id %q not found in key set", kid)
+	}
+	var claims jwt.Claims
+	if err := tok.Claims(keys[0].Key, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "user" {
+		t.Errorf("subject = %q, want %q", claims.Subject, "user")
+	}
+}
@@ -87,6 +87,12 @@ func (e *HTTPError) ErrorResponse(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
-		errorTemplate.ExecuteTemplate(w, "error.html", response)
+		tmpl := lookupTemplate(r, TemplateError)
+		if e.Debugable() {
+			if forbidden := lookupTemplate(r, TemplateForbidden); forbidden != nil {
+				tmpl = forbidden
+			}
+		}
+		tmpl.Execute(w, response)
 	}
 }
@@ -18,6 +18,10 @@ type ServerOptions struct {
 	ReadTimeout       time.Duration
 	WriteTimeout      time.Duration
 	IdleTimeout       time.Duration
+
+	// H2C accepts cleartext HTTP/2 connections, as gRPC clients make, on
+	// servers without TLS.
+	H2C bool
 }
 
 var defaultServerOptions = &ServerOptions{
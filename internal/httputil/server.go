@@ -13,6 +13,9 @@ import (
 	"syscall"
 	"time"
 
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/urlutil"
 )
@@ -32,6 +35,9 @@ func NewServer(opt *ServerOptions, h http.Handler, wg *sync.WaitGroup) (*http.Se
 	}
 	if opt.TLSCertificate != nil {
 		ln = tls.NewListener(ln, newDefaultTLSConfig(opt.TLSCertificate))
+	} else if opt.H2C {
+		// without TLS, HTTP/2 clients (e.g. gRPC) must use cleartext HTTP/2
+		h = h2c.NewHandler(h, &http2.Server{})
 	}
 	sublogger := log.With().Str("addr", opt.Addr).Logger()
 
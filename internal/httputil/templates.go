@@ -0,0 +1,264 @@
+package httputil // import "github.com/pomerium/pomerium/internal/httputil"
+
+import (
+	"context"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/pomerium/pomerium/internal/frontend"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+const (
+	// TemplateError is the name of the template errors are rendered with.
+	TemplateError = "error.html"
+	// TemplateForbidden is the name of the template unauthenticated, and
+	// unauthorized, errors are rendered with. Falls back to TemplateError.
+	TemplateForbidden = "forbidden.html"
+	// TemplateSignIn is the name of the template shown to users before they
+	// are redirected to sign in. If undefined, users are redirected directly.
+	TemplateSignIn = "sign_in.html"
+)
+
+// Templates are pomerium's html templates, overridden by those in one or more
+// directories. Each `.html` file in a directory defines a template named
+// after the file, and directories later in the list take precedence. The
+// templates are reloaded when a directory's contents change.
+type Templates struct {
+	dirs    []string
+	watcher *fsnotify.Watcher
+
+	mu sync.RWMutex
+	t  *template.Template
+}
+
+// LoadTemplates loads, validates, and watches the templates in dirs. The
+// templates must be closed once no longer used.
+func LoadTemplates(dirs ...string) (*Templates, error) {
+	t := &Templates{dirs: dirs}
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	if err := t.watch(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Close stops watching the templates' directories.
+func (t *Templates) Close() error {
+	return t.watcher.Close()
+}
+
+// TemplatesCache shares templates between the users of the same directories,
+// such as a service's routes, across reloads of the service's configuration.
+type TemplatesCache struct {
+	mu        sync.Mutex
+	templates map[string]*Templates
+	// loading are the templates used by the configuration being loaded
+	loading map[string]*Templates
+}
+
+// NewTemplatesCache returns a new, empty, templates cache.
+func NewTemplatesCache() *TemplatesCache {
+	return &TemplatesCache{templates: make(map[string]*Templates)}
+}
+
+// BeginReload starts loading a new configuration.
+func (c *TemplatesCache) BeginReload() {
+	c.mu.Lock()
+	c.loading = make(map[string]*Templates)
+	c.mu.Unlock()
+}
+
+// Load returns the templates for dirs, loading them if they are not cached.
+func (c *TemplatesCache) Load(dirs ...string) (*Templates, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := strings.Join(dirs, string(filepath.ListSeparator))
+	t, ok := c.templates[key]
+	if !ok {
+		var err error
+		if t, err = LoadTemplates(dirs...); err != nil {
+			return nil, err
+		}
+		c.templates[key] = t
+	}
+	if c.loading != nil {
+		c.loading[key] = t
+	}
+	return t, nil
+}
+
+// EndReload closes the templates that are not used by the configuration
+// loaded since BeginReload.
+func (c *TemplatesCache) EndReload() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.loading == nil {
+		return
+	}
+	for key, t := range c.templates {
+		if _, ok := c.loading[key]; !ok {
+			t.Close()
+		}
+	}
+	c.templates, c.loading = c.loading, nil
+}
+
+// Lookup returns the template with the given name, or nil if there is none.
+func (t *Templates) Lookup(name string) *template.Template {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Lookup(name)
+}
+
+func (t *Templates) load() error {
+	tmpl, err := frontend.NewTemplates()
+	if err != nil {
+		return err
+	}
+	for _, dir := range t.dirs {
+		if _, err := os.Stat(dir); err != nil {
+			return fmt.Errorf("internal/httputil: bad templates directory: %w", err)
+		}
+		files, err := filepath.Glob(filepath.Join(dir, "*.html"))
+		if err != nil {
+			return fmt.Errorf("internal/httputil: bad templates directory %s: %w", dir, err)
+		}
+		for _, file := range files {
+			b, err := ioutil.ReadFile(file)
+			if err != nil {
+				return fmt.Errorf("internal/httputil: error reading template: %w", err)
+			}
+			if _, err := tmpl.New(filepath.Base(file)).Parse(string(b)); err != nil {
+				return fmt.Errorf("internal/httputil: bad template %s: %w", file, err)
+			}
+		}
+	}
+	if err := validateTemplates(tmpl); err != nil {
+		return err
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.t = tmpl
+	return nil
+}
+
+// validateTemplates renders the error and sign in templates with example
+// data, so that references to unknown fields fail before users see them.
+func validateTemplates(tmpl *template.Template) error {
+	// executing a template prevents any more from being parsed into it
+	tmpl, err := tmpl.Clone()
+	if err != nil {
+		return err
+	}
+	examples := map[string]interface{}{
+		TemplateError:     errResponse{Status: http.StatusBadGateway, StatusText: http.StatusText(http.StatusBadGateway)},
+		TemplateForbidden: errResponse{Status: http.StatusForbidden, StatusText: http.StatusText(http.StatusForbidden), CanDebug: true},
+		TemplateSignIn:    signInResponse{SignInURL: "https://authenticate.example.com/.pomerium/sign_in"},
+	}
+	for name, data := range examples {
+		if tmpl.Lookup(name) == nil {
+			continue
+		}
+		if err := tmpl.ExecuteTemplate(ioutil.Discard, name, data); err != nil {
+			return fmt.Errorf("internal/httputil: bad template %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// watch reloads the templates when a file in any of their directories
+// changes. Templates that fail to reload are logged, and the previous
+// templates kept.
+func (t *Templates) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	for _, dir := range t.dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return fmt.Errorf("internal/httputil: could not watch templates: %w", err)
+		}
+	}
+	t.watcher = watcher
+	go func() {
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+				if filepath.Ext(event.Name) != ".html" {
+					continue
+				}
+				if err := t.load(); err != nil {
+					log.Error().Err(err).Msg("internal/httputil: could not reload templates")
+					continue
+				}
+				log.Info().Str("file", event.Name).Msg("internal/httputil: templates reloaded")
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+				log.Error().Err(err).Msg("internal/httputil: templates watcher")
+			}
+		}
+	}()
+	return nil
+}
+
+type templatesKey struct{}
+
+// SetTemplates is middleware that renders the error, and sign in, pages of
+// requests with the given templates.
+func SetTemplates(t *Templates) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), templatesKey{}, t)))
+		})
+	}
+}
+
+// lookupTemplate returns the request's template with the given name, falling
+// back to pomerium's own templates. It returns nil if there is none.
+func lookupTemplate(r *http.Request, name string) *template.Template {
+	if t, ok := r.Context().Value(templatesKey{}).(*Templates); ok {
+		return t.Lookup(name)
+	}
+	return errorTemplate.Lookup(name)
+}
+
+type signInResponse struct {
+	SignInURL string
+	Version   string
+}
+
+// RedirectToSignIn sends the user to sign in. If a sign in template is set,
+// it is shown to the user first, otherwise they are redirected directly.
+func RedirectToSignIn(w http.ResponseWriter, r *http.Request, signInURL string) {
+	tmpl := lookupTemplate(r, TemplateSignIn)
+	if tmpl == nil || r.Header.Get("Accept") == "application/json" {
+		Redirect(w, r, signInURL, http.StatusFound)
+		return
+	}
+	w.Header().Set(HeaderPomeriumResponse, "true")
+	w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+	if err := tmpl.Execute(w, signInResponse{SignInURL: signInURL, Version: fullVersion}); err != nil {
+		log.FromRequest(r).Error().Err(err).Msg("httputil: sign in template")
+	}
+}
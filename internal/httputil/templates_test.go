@@ -0,0 +1,233 @@
+package httputil
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// templatesDir returns a temporary directory containing the given templates.
+func templatesDir(t *testing.T, templates map[string]string) string {
+	t.Helper()
+	dir, err := ioutil.TempDir("", "pomerium-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range templates {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoadTemplates(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		templates map[string]string
+		wantErr   bool
+	}{
+		{"good", map[string]string{"error.html": "{{.Status}} {{.Error}}", "forbidden.html": "{{if .CanDebug}}debug{{end}}", "sign_in.html": `<a href="{{.SignInURL}}">sign in</a>`}, false},
+		{"built in partials", map[string]string{"error.html": `{{template "header.html"}}{{.StatusText}}`}, false},
+		{"other files ignored", map[string]string{"README.md": "{{"}, false},
+		{"bad syntax", map[string]string{"error.html": "{{.Status"}, true},
+		{"unknown field", map[string]string{"forbidden.html": "{{.Email}}"}, true},
+		{"unknown sign in field", map[string]string{"sign_in.html": "{{.RedirectURL}}"}, true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			dir := templatesDir(t, tt.templates)
+			defer os.RemoveAll(dir)
+			templates, err := LoadTemplates(dir)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				templates.Close()
+			}
+		})
+	}
+	t.Run("missing directory", func(t *testing.T) {
+		t.Parallel()
+		if _, err := LoadTemplates(filepath.Join(os.TempDir(), "pomerium-templates-missing")); err == nil {
+			t.Error("LoadTemplates() expected an error")
+		}
+	})
+}
+
+func TestHTTPError_ErrorResponse_Templates(t *testing.T) {
+	t.Parallel()
+	global := templatesDir(t, map[string]string{"error.html": "global error {{.Status}}", "forbidden.html": "global forbidden"})
+	defer os.RemoveAll(global)
+	route := templatesDir(t, map[string]string{"error.html": "route error {{.Status}}"})
+	defer os.RemoveAll(route)
+
+	tests := []struct {
+		name     string
+		dirs     []string
+		status   int
+		wantBody string
+	}{
+		{"global error", []string{global}, http.StatusBadGateway, "global error 502"},
+		{"global forbidden", []string{global}, http.StatusForbidden, "global forbidden"},
+		{"route error", []string{global, route}, http.StatusBadGateway, "route error 502"},
+		{"route falls back to global forbidden", []string{global, route}, http.StatusUnauthorized, "global forbidden"},
+		{"forbidden falls back to error", []string{route}, http.StatusForbidden, "route error 403"},
+		{"built in", nil, http.StatusBadGateway, "<!DOCTYPE html>"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h http.Handler = HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+				return NewError(tt.status, errors.New("error"))
+			})
+			if tt.dirs != nil {
+				templates, err := LoadTemplates(tt.dirs...)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer templates.Close()
+				h = SetTemplates(templates)(h)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+			if w.Code != tt.status {
+				t.Errorf("status = %d, want %d", w.Code, tt.status)
+			}
+			if body := w.Body.String(); !strings.Contains(body, tt.wantBody) {
+				t.Errorf("body = %q, want %q", body, tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestRedirectToSignIn(t *testing.T) {
+	t.Parallel()
+	dir := templatesDir(t, map[string]string{"sign_in.html": `<a href="{{.SignInURL}}">sign in</a>`})
+	defer os.RemoveAll(dir)
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer templates.Close()
+	tests := []struct {
+		name       string
+		templates  *Templates
+		accept     string
+		wantStatus int
+		wantBody   string
+	}{
+		{"no template", nil, "", http.StatusFound, ""},
+		{"template", templates, "", http.StatusOK, `<a href="https://authenticate.example/sign_in?a=b&amp;c=d">sign in</a>`},
+		{"json", templates, "application/json", http.StatusFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var h http.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				RedirectToSignIn(w, r, "https://authenticate.example/sign_in?a=b&c=d")
+			})
+			if tt.templates != nil {
+				h = SetTemplates(tt.templates)(h)
+			}
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header.Set("Accept", tt.accept)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantBody != "" && w.Body.String() != tt.wantBody {
+				t.Errorf("body = %q, want %q", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+func TestTemplates_reload(t *testing.T) {
+	t.Parallel()
+	dir := templatesDir(t, map[string]string{"error.html": "before"})
+	defer os.RemoveAll(dir)
+	templates, err := LoadTemplates(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer templates.Close()
+	// replace the template atomically, so it is never seen partly written
+	write := func(content string) {
+		tmp := filepath.Join(dir, "error.tmp")
+		if err := ioutil.WriteFile(tmp, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Rename(tmp, filepath.Join(dir, "error.html")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	render := func() string {
+		var b strings.Builder
+		templates.Lookup(TemplateError).Execute(&b, errResponse{})
+		return b.String()
+	}
+
+	// invalid templates are not reloaded
+	write("{{.Status")
+	time.Sleep(100 * time.Millisecond)
+	if got := render(); got != "before" {
+		t.Fatalf("template = %q, want %q", got, "before")
+	}
+
+	write("after")
+	deadline := time.Now().Add(5 * time.Second)
+	for render() != "after" {
+		if time.Now().After(deadline) {
+			t.Fatalf("template = %q, want %q", render(), "after")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestTemplatesCache(t *testing.T) {
+	t.Parallel()
+	a := templatesDir(t, map[string]string{"error.html": "a"})
+	defer os.RemoveAll(a)
+	b := templatesDir(t, map[string]string{"error.html": "b"})
+	defer os.RemoveAll(b)
+
+	c := NewTemplatesCache()
+	reload := func(dirs ...string) *Templates {
+		t.Helper()
+		c.BeginReload()
+		defer c.EndReload()
+		templates, err := c.Load(dirs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// later routes with the same directories share the templates
+		if again, _ := c.Load(dirs...); again != templates {
+			t.Errorf("Load() loaded the same directories twice")
+		}
+		return templates
+	}
+
+	first := reload(a)
+	if got := reload(a); got != first {
+		t.Errorf("reload with the same directories loaded new templates")
+	}
+	reload(a, b)
+	if len(c.templates) != 1 {
+		t.Errorf("templates not used after reload were kept: %v", c.templates)
+	}
+	if got := reload(a); got == first {
+		t.Errorf("reload reused closed templates")
+	}
+	if _, err := c.Load(filepath.Join(os.TempDir(), "pomerium-templates-missing")); err == nil {
+		t.Error("Load() expected an error")
+	}
+}
@@ -0,0 +1,226 @@
+package sessions // import "github.com/pomerium/pomerium/internal/sessions"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrRevoked indicates that the session has been revoked.
+	ErrRevoked = errors.New("internal/sessions: session has been revoked")
+
+	// ErrUnknownSession indicates that the session is not in the inventory.
+	ErrUnknownSession = errors.New("internal/sessions: session is not known")
+)
+
+// Record describes a user's session, as tracked by an Inventory.
+type Record struct {
+	ID        string    `json:"id"`
+	User      string    `json:"user"`
+	Email     string    `json:"email,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	IPAddress string    `json:"ip_address,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// Expiry is when the session, unless refreshed, can no longer be used.
+	Expiry time.Time `json:"expiry"`
+}
+
+// RevocationChecker reports whether sessions have been revoked.
+type RevocationChecker interface {
+	IsRevoked(ctx context.Context, s *State) bool
+}
+
+// Inventory keeps track of users' active sessions, by session id, so that
+// they can be listed and revoked.
+type Inventory interface {
+	RevocationChecker
+	// Add tracks a new session or, if it is already tracked, extends its
+	// expiry.
+	Add(ctx context.Context, r Record) error
+	// List returns the user's active sessions, oldest first.
+	List(ctx context.Context, user string) ([]Record, error)
+	// Revoke revokes the session with the given id. If user is set, the
+	// session must belong to them.
+	Revoke(ctx context.Context, id, user string) error
+	// RevokedIDs returns the ids of all revoked sessions.
+	RevokedIDs(ctx context.Context) ([]string, error)
+}
+
+// MemoryInventory is an Inventory kept in memory. Sessions are forgotten
+// once expired, and revoked session ids after revokedTTL.
+//
+// As it is not shared, a restart forgets every revocation, and each replica
+// of a service has its own inventory.
+type MemoryInventory struct {
+	revokedTTL time.Duration
+
+	mu       sync.RWMutex
+	sessions map[string]Record
+	revoked  map[string]time.Time
+}
+
+// NewMemoryInventory returns a new in memory session inventory that
+// remembers revoked sessions for revokedTTL.
+func NewMemoryInventory(revokedTTL time.Duration) *MemoryInventory {
+	return &MemoryInventory{
+		revokedTTL: revokedTTL,
+		sessions:   make(map[string]Record),
+		revoked:    make(map[string]time.Time),
+	}
+}
+
+// Add tracks a new session or, if it is already tracked, extends its expiry.
+// Revoked sessions are not tracked again.
+func (mi *MemoryInventory) Add(_ context.Context, r Record) error {
+	if r.ID == "" {
+		return ErrUnknownSession
+	}
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	mi.prune(timeNow())
+	if _, ok := mi.revoked[r.ID]; ok {
+		return ErrRevoked
+	}
+	if existing, ok := mi.sessions[r.ID]; ok {
+		existing.Email = r.Email
+		existing.Expiry = r.Expiry
+		r = existing
+	}
+	mi.sessions[r.ID] = r
+	return nil
+}
+
+// List returns the user's active sessions, oldest first.
+func (mi *MemoryInventory) List(_ context.Context, user string) ([]Record, error) {
+	mi.mu.RLock()
+	defer mi.mu.RUnlock()
+	now := timeNow()
+	var records []Record
+	for _, r := range mi.sessions {
+		if r.User == user && now.Before(r.Expiry) {
+			records = append(records, r)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+	return records, nil
+}
+
+// Revoke revokes the session with the given id. If user is set, the session
+// must belong to them.
+func (mi *MemoryInventory) Revoke(_ context.Context, id, user string) error {
+	mi.mu.Lock()
+	defer mi.mu.Unlock()
+	r, ok := mi.sessions[id]
+	if !ok || (user != "" && r.User != user) {
+		return ErrUnknownSession
+	}
+	delete(mi.sessions, id)
+	mi.revoked[id] = timeNow().Add(mi.revokedTTL)
+	return nil
+}
+
+// RevokedIDs returns the ids of all revoked sessions.
+func (mi *MemoryInventory) RevokedIDs(_ context.Context) ([]string, error) {
+	mi.mu.RLock()
+	defer mi.mu.RUnlock()
+	now := timeNow()
+	ids := make([]string, 0, len(mi.revoked))
+	for id, expiry := range mi.revoked {
+		if now.Before(expiry) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// IsRevoked reports whether the session has been revoked.
+func (mi *MemoryInventory) IsRevoked(_ context.Context, s *State) bool {
+	mi.mu.RLock()
+	defer mi.mu.RUnlock()
+	expiry, ok := mi.revoked[s.ID]
+	return ok && timeNow().Before(expiry)
+}
+
+// prune forgets expired sessions and revocations. mu must be held.
+func (mi *MemoryInventory) prune(now time.Time) {
+	for id, r := range mi.sessions {
+		if !now.Before(r.Expiry) {
+			delete(mi.sessions, id)
+		}
+	}
+	for id, expiry := range mi.revoked {
+		if !now.Before(expiry) {
+			delete(mi.revoked, id)
+		}
+	}
+}
+
+// NewRecord returns the inventory record of a session created by the given
+// request, that expires at expiry.
+func NewRecord(r *http.Request, s *State, expiry time.Time) Record {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	return Record{
+		ID:        s.ID,
+		User:      s.Subject,
+		Email:     s.Email,
+		UserAgent: r.UserAgent(),
+		IPAddress: ip,
+		CreatedAt: timeNow(),
+		Expiry:    expiry,
+	}
+}
+
+// revocationLoader rejects sessions, loaded by a SessionLoader, that have
+// been revoked.
+type revocationLoader struct {
+	SessionLoader
+	checker RevocationChecker
+}
+
+// NewRevocationLoader returns a SessionLoader that loads sessions with l,
+// failing with ErrRevoked for sessions the checker reports as revoked.
+func NewRevocationLoader(l SessionLoader, checker RevocationChecker) SessionLoader {
+	return &revocationLoader{SessionLoader: l, checker: checker}
+}
+
+// LoadSession loads the session, failing if it has been revoked. Like
+// failures to verify a session, the revoked session is returned with the
+// error.
+func (rl *revocationLoader) LoadSession(r *http.Request) (*State, error) {
+	s, err := rl.SessionLoader.LoadSession(r)
+	if err != nil {
+		return s, err
+	}
+	if s != nil && s.ID != "" && rl.checker.IsRevoked(r.Context(), s) {
+		return s, ErrRevoked
+	}
+	return s, nil
+}
+
+// revocationStore is a SessionStore that fails to load revoked sessions.
+type revocationStore struct {
+	SessionStore
+	loader SessionLoader
+}
+
+// NewRevocationStore returns a SessionStore that saves and clears sessions
+// with s, and, like NewRevocationLoader, fails to load revoked sessions.
+func NewRevocationStore(s SessionStore, checker RevocationChecker) SessionStore {
+	return &revocationStore{SessionStore: s, loader: NewRevocationLoader(s, checker)}
+}
+
+// LoadSession loads the session, failing if it has been revoked.
+func (rs *revocationStore) LoadSession(r *http.Request) (*State, error) {
+	return rs.loader.LoadSession(r)
+}
@@ -0,0 +1,129 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMemoryInventory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time { return now }
+	defer func() { timeNow = time.Now }()
+
+	mi := NewMemoryInventory(time.Hour)
+	session := func(id string) *State { return &State{ID: id} }
+	records := []Record{
+		{ID: "a", User: "user", CreatedAt: now, Expiry: now.Add(time.Hour)},
+		{ID: "b", User: "user", CreatedAt: now.Add(time.Minute), Expiry: now.Add(time.Minute)},
+		{ID: "c", User: "other", CreatedAt: now, Expiry: now.Add(time.Hour)},
+	}
+	for _, r := range records {
+		if err := mi.Add(ctx, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := mi.Add(ctx, Record{User: "user"}); !errors.Is(err, ErrUnknownSession) {
+		t.Errorf("Add() without id error = %v, want %v", err, ErrUnknownSession)
+	}
+
+	list := func(user string) []Record {
+		t.Helper()
+		got, err := mi.List(ctx, user)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	if diff := cmp.Diff(records[:2], list("user")); diff != "" {
+		t.Errorf("List() = %s", diff)
+	}
+
+	// refreshing a session extends its expiry, but keeps when it was created
+	if err := mi.Add(ctx, Record{ID: "b", User: "user", CreatedAt: now.Add(time.Hour), Expiry: now.Add(2 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Minute)
+	if got := list("user"); len(got) != 2 || got[1].ID != "b" || !got[1].CreatedAt.Equal(records[1].CreatedAt) {
+		t.Errorf("List() after refresh = %v", got)
+	}
+
+	tests := []struct {
+		name    string
+		id      string
+		user    string
+		wantErr error
+	}{
+		{"other user's session", "c", "user", ErrUnknownSession},
+		{"unknown session", "d", "user", ErrUnknownSession},
+		{"own session", "a", "user", nil},
+		{"already revoked", "a", "user", ErrUnknownSession},
+		{"any user's session", "c", "", nil},
+	}
+	for _, tt := range tests {
+		if err := mi.Revoke(ctx, tt.id, tt.user); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: Revoke() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+	if got := list("user"); len(got) != 1 || got[0].ID != "b" {
+		t.Errorf("List() after revoke = %v", got)
+	}
+	if !mi.IsRevoked(ctx, session("a")) || mi.IsRevoked(ctx, session("b")) {
+		t.Error("IsRevoked() wrong")
+	}
+	if err := mi.Add(ctx, records[0]); !errors.Is(err, ErrRevoked) {
+		t.Errorf("Add() revoked session error = %v, want %v", err, ErrRevoked)
+	}
+	if ids, _ := mi.RevokedIDs(ctx); !cmp.Equal(ids, []string{"a", "c"}) {
+		t.Errorf("RevokedIDs() = %v", ids)
+	}
+
+	// revocations are forgotten after their ttl
+	now = now.Add(time.Hour)
+	if mi.IsRevoked(ctx, session("a")) {
+		t.Error("revocation not forgotten")
+	}
+	if ids, _ := mi.RevokedIDs(ctx); len(ids) != 0 {
+		t.Errorf("RevokedIDs() = %v, want none", ids)
+	}
+}
+
+type revokedChecker map[string]bool
+
+func (c revokedChecker) IsRevoked(_ context.Context, s *State) bool { return c[s.ID] }
+
+func TestRevocationLoader(t *testing.T) {
+	t.Parallel()
+	checker := revokedChecker{"revoked": true}
+	tests := []struct {
+		name    string
+		store   *MockSessionStore
+		wantErr error
+	}{
+		{"good", &MockSessionStore{Session: &State{ID: "good"}}, nil},
+		{"no id", &MockSessionStore{Session: &State{}}, nil},
+		{"revoked", &MockSessionStore{Session: &State{ID: "revoked"}}, ErrRevoked},
+		{"load error", &MockSessionStore{LoadError: ErrNoSessionFound}, ErrNoSessionFound},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for _, l := range []SessionLoader{NewRevocationLoader(tt.store, checker), NewRevocationStore(tt.store, checker)} {
+				s, err := l.LoadSession(httptest.NewRequest(http.MethodGet, "/", nil))
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("LoadSession() error = %v, want %v", err, tt.wantErr)
+				}
+				if err == nil && s != tt.store.Session {
+					t.Errorf("LoadSession() = %v, want %v", s, tt.store.Session)
+				}
+			}
+		})
+	}
+}
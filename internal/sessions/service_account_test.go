@@ -0,0 +1,39 @@
+package sessions
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestNewServiceAccount(t *testing.T) {
+	t.Parallel()
+	expiry := time.Now().Add(time.Hour)
+	s := NewServiceAccount("id", "ci", "ci@corp.example", []string{"deployers"}, []string{"httpbin.corp.example"}, expiry)
+	if !s.Programmatic || !s.ServiceAccount || s.ID != "id" || s.Subject != "ci" {
+		t.Errorf("NewServiceAccount() = %+v", s)
+	}
+	if err := s.Verify("httpbin.corp.example"); err != nil {
+		t.Errorf("Verify() error = %v", err)
+	}
+	if err := s.Verify("other.corp.example"); err != ErrInvalidAudience {
+		t.Errorf("Verify() other route error = %v, want %v", err, ErrInvalidAudience)
+	}
+	expired := NewServiceAccount("id", "ci", "", nil, nil, time.Now().Add(-time.Hour))
+	if err := expired.Verify("httpbin.corp.example"); err != ErrExpired {
+		t.Errorf("Verify() expired error = %v, want %v", err, ErrExpired)
+	}
+}
+
+func TestRevocationList(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	rl := NewRevocationList([]string{"a"})
+	if !rl.IsRevoked(ctx, &State{ID: "a"}) || rl.IsRevoked(ctx, &State{ID: "b"}) {
+		t.Error("IsRevoked() wrong")
+	}
+	rl.Set([]string{"b"})
+	if rl.IsRevoked(ctx, &State{ID: "a"}) || !rl.IsRevoked(ctx, &State{ID: "b"}) {
+		t.Error("IsRevoked() after Set() wrong")
+	}
+}
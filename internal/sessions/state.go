@@ -50,8 +50,14 @@ type State struct {
 	// Programmatic whether this state is used for machine-to-machine
 	// programatic access.
 	Programmatic bool `json:"programatic"`
+	// ServiceAccount is whether this state is an administrator issued
+	// service account, which cannot be refreshed.
+	ServiceAccount bool `json:"service_account,omitempty"`
 
 	AccessToken *oauth2.Token `json:"access_token,omitempty"`
+	// RawIDToken is the identity provider issued id token the session was
+	// last created, or refreshed, with.
+	RawIDToken string `json:"id_token,omitempty"`
 
 	idToken *oidc.IDToken
 }
@@ -73,6 +79,7 @@ func NewStateFromTokens(idToken *oidc.IDToken, accessToken *oauth2.Token, audien
 	s.Audience = []string{audience}
 	s.idToken = idToken
 	s.AccessToken = accessToken
+	s.RawIDToken, _ = accessToken.Extra("id_token").(string)
 
 	return s, nil
 }
@@ -89,11 +96,15 @@ func (s *State) UpdateState(idToken *oidc.IDToken, accessToken *oauth2.Token) er
 		return errors.New("sessions: oauth2 token missing")
 	}
 	audience := append(s.Audience[:0:0], s.Audience...)
+	// the session keeps its id, even if the identity provider sets a jti
+	id := s.ID
 	s.AccessToken = accessToken
+	s.RawIDToken, _ = accessToken.Extra("id_token").(string)
 	if err := idToken.Claims(s); err != nil {
 		return fmt.Errorf("sessions: update state failed %w", err)
 	}
 	s.Audience = audience
+	s.ID = id
 	s.Expiry = jwt.NewNumericDate(accessToken.Expiry)
 	return nil
 }
@@ -108,9 +119,10 @@ func (s State) NewSession(issuer string, audience []string) *State {
 	return &s
 }
 
-// RouteSession creates a route session with access tokens stripped.
+// RouteSession creates a route session with access and id tokens stripped.
 func (s State) RouteSession() *State {
 	s.AccessToken = nil
+	s.RawIDToken = ""
 	return &s
 }
 
@@ -103,6 +103,7 @@ func TestState_RouteSession(t *testing.T) {
 		want *State
 	}{
 		{"good", "authenticate.x.y.z", []string{"http.x.y.z"}, jwt.NewNumericDate(timeNow()), nil, "authenticate.a.b.c", []string{"http.a.b.c"}, &State{Issuer: "authenticate.a.b.c", Audience: []string{"http.a.b.c"}, NotBefore: jwt.NewNumericDate(timeNow()), IssuedAt: jwt.NewNumericDate(timeNow()), Expiry: jwt.NewNumericDate(timeNow())}},
+		{"strips tokens", "authenticate.x.y.z", []string{"http.x.y.z"}, jwt.NewNumericDate(timeNow()), &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}, "authenticate.a.b.c", []string{"http.a.b.c"}, &State{Issuer: "authenticate.a.b.c", Audience: []string{"http.a.b.c"}, NotBefore: jwt.NewNumericDate(timeNow()), IssuedAt: jwt.NewNumericDate(timeNow()), Expiry: jwt.NewNumericDate(timeNow())}},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -111,6 +112,7 @@ func TestState_RouteSession(t *testing.T) {
 				Audience:    tt.Audience,
 				Expiry:      tt.Expiry,
 				AccessToken: tt.AccessToken,
+				RawIDToken:  "id.token",
 			}
 			cmpOpts := []cmp.Option{
 				cmpopts.IgnoreUnexported(State{}),
@@ -0,0 +1,45 @@
+// Package tcputil provides helpers for tunneling TCP connections.
+package tcputil // import "github.com/pomerium/pomerium/internal/tcputil"
+
+import (
+	"io"
+	"net"
+	"sync"
+)
+
+// Splice copies bytes between a client and an upstream connection until both
+// directions are done. Either side is read from its reader, which may buffer
+// bytes already read from the connection. When one side stops sending, the
+// other is told it will receive no more data, so that half-closed
+// connections keep working. If the client connection is closed, so is the
+// upstream.
+func Splice(client net.Conn, clientReader io.Reader, upstream net.Conn, upstreamReader io.Reader) {
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		if _, err := io.Copy(upstream, clientReader); err != nil {
+			// the client connection was closed, e.g. once it was no longer
+			// authorized, rather than half-closed
+			upstream.Close()
+			return
+		}
+		closeWrite(upstream)
+	}()
+	go func() {
+		defer wg.Done()
+		io.Copy(client, upstreamReader)
+		closeWrite(client)
+	}()
+	wg.Wait()
+}
+
+// closeWrite shuts down the writing side of a connection if it is supported,
+// otherwise the connection is closed.
+func closeWrite(conn net.Conn) {
+	if cw, ok := conn.(interface{ CloseWrite() error }); ok {
+		cw.CloseWrite()
+		return
+	}
+	conn.Close()
+}
@@ -0,0 +1,88 @@
+package tcputil
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+)
+
+// tcpPipe returns both ends of a loopback tcp connection.
+func tcpPipe(t *testing.T) (*net.TCPConn, *net.TCPConn) {
+	t.Helper()
+	li, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer li.Close()
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, _ := li.Accept()
+		accepted <- conn
+	}()
+	conn, err := net.Dial("tcp", li.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return conn.(*net.TCPConn), (<-accepted).(*net.TCPConn)
+}
+
+func TestSplice(t *testing.T) {
+	t.Parallel()
+	client, clientServer := tcpPipe(t)
+	defer client.Close()
+	defer clientServer.Close()
+	upstreamClient, upstream := tcpPipe(t)
+	defer upstreamClient.Close()
+	defer upstream.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// the reader may hold bytes the client already sent
+		Splice(clientServer, io.MultiReader(strings.NewReader("hello"), clientServer), upstreamClient, upstreamClient)
+	}()
+
+	io.WriteString(client, " world")
+	client.CloseWrite()
+	got, err := ioutil.ReadAll(upstream)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello world" {
+		t.Errorf("upstream got %q, want %q", got, "hello world")
+	}
+	// the upstream can still respond once the client is done sending
+	io.WriteString(upstream, "bye")
+	upstream.CloseWrite()
+	got, err = ioutil.ReadAll(client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "bye" {
+		t.Errorf("client got %q, want %q", got, "bye")
+	}
+	<-done
+}
+
+func TestSplice_clientClosed(t *testing.T) {
+	t.Parallel()
+	client, clientServer := tcpPipe(t)
+	defer client.Close()
+	upstreamClient, upstream := tcpPipe(t)
+	defer upstream.Close()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Splice(clientServer, clientServer, upstreamClient, upstreamClient)
+	}()
+	// closing the client's connection, rather than half-closing it, closes
+	// the upstream's too
+	clientServer.Close()
+	if _, err := ioutil.ReadAll(upstream); err != nil {
+		t.Fatal(err)
+	}
+	<-done
+}
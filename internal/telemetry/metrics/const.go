@@ -14,6 +14,12 @@ var (
 	TagKeyGRPCMethod  = tag.MustNewKey("grpc_method")
 	TagKeyHost        = tag.MustNewKey("host")
 	TagKeyDestination = tag.MustNewKey("destination")
+	// TagKeyDestinationGroup is the destination group, of a route with
+	// weighted destination groups, a request was sent to.
+	TagKeyDestinationGroup = tag.MustNewKey("destination_group")
+	// TagKeyCircuitBreaker is the circuit breaker, of the breakers sending
+	// requests to a destination, a state is recorded for.
+	TagKeyCircuitBreaker = tag.MustNewKey("circuit_breaker")
 )
 
 // Default distributions used by views in this package.
@@ -37,7 +43,7 @@ var (
 		GRPCServerViews,
 		HTTPServerViews,
 		GRPCClientViews,
-		GRPCServerViews,
+		HTTPClientViews,
 		InfoViews,
 	}
 )
@@ -1,12 +1,14 @@
 package metrics // import "github.com/pomerium/pomerium/internal/telemetry/metrics"
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/tripper"
 	"go.opencensus.io/plugin/ochttp"
+	"go.opencensus.io/stats"
 	"go.opencensus.io/stats/view"
 	"go.opencensus.io/tag"
 )
@@ -17,7 +19,12 @@ var (
 	HTTPClientViews = []*view.View{
 		HTTPClientRequestCountView,
 		HTTPClientRequestDurationView,
-		HTTPClientResponseSizeView}
+		HTTPClientResponseSizeView,
+		HTTPClientRetriesView,
+		HTTPClientRetriesExhaustedView,
+		HTTPClientCircuitBreakerStateView,
+		HTTPClientCacheHitsView,
+		HTTPClientCacheMissesView}
 	// HTTPServerViews contains opencensus views for HTTP Server metrics.
 	HTTPServerViews = []*view.View{
 		HTTPServerRequestCountView,
@@ -71,7 +78,7 @@ var (
 		Name:        "http/client/requests_total",
 		Measure:     ochttp.ClientRoundtripLatency,
 		Description: "Total HTTP Client Requests",
-		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, ochttp.StatusCode, TagKeyDestination},
+		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, ochttp.StatusCode, TagKeyDestination, TagKeyDestinationGroup},
 		Aggregation: view.Count(),
 	}
 
@@ -81,7 +88,7 @@ var (
 		Name:        "http/client/request_duration_ms",
 		Measure:     ochttp.ClientRoundtripLatency,
 		Description: "HTTP Client Request duration in ms",
-		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, ochttp.StatusCode, TagKeyDestination},
+		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, ochttp.StatusCode, TagKeyDestination, TagKeyDestinationGroup},
 		Aggregation: DefaultHTTPLatencyDistrubtion,
 	}
 
@@ -91,7 +98,7 @@ var (
 		Name:        "http/client/response_size_bytes",
 		Measure:     ochttp.ClientReceivedBytes,
 		Description: "HTTP Client Response Size in bytes",
-		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, ochttp.StatusCode, TagKeyDestination},
+		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, ochttp.StatusCode, TagKeyDestination, TagKeyDestinationGroup},
 		Aggregation: DefaulHTTPSizeDistribution,
 	}
 
@@ -101,9 +108,85 @@ var (
 		Name:        "http/client/response_size_bytes",
 		Measure:     ochttp.ClientSentBytes,
 		Description: "HTTP Client Response Size in bytes",
-		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, TagKeyDestination},
+		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, TagKeyDestination, TagKeyDestinationGroup},
 		Aggregation: DefaulHTTPSizeDistribution,
 	}
+
+	httpClientRetries = stats.Int64(
+		"http/client/retries",
+		"HTTP Client Request Retries",
+		stats.UnitDimensionless)
+	httpClientRetriesExhausted = stats.Int64(
+		"http/client/retries_exhausted",
+		"HTTP Client Requests that failed after exhausting all retries",
+		stats.UnitDimensionless)
+
+	// HTTPClientRetriesView is an OpenCensus view that tracks HTTP client
+	// request retries by pomerium service, destination, host and method
+	HTTPClientRetriesView = &view.View{
+		Name:        "http/client/retries_total",
+		Measure:     httpClientRetries,
+		Description: httpClientRetries.Description(),
+		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, TagKeyDestination, TagKeyDestinationGroup},
+		Aggregation: view.Count(),
+	}
+
+	// HTTPClientRetriesExhaustedView is an OpenCensus view that tracks HTTP
+	// client requests that exhausted all retries by pomerium service,
+	// destination, host and method
+	HTTPClientRetriesExhaustedView = &view.View{
+		Name:        "http/client/retries_exhausted_total",
+		Measure:     httpClientRetriesExhausted,
+		Description: httpClientRetriesExhausted.Description(),
+		TagKeys:     []tag.Key{TagKeyService, TagKeyHost, TagKeyHTTPMethod, TagKeyDestination, TagKeyDestinationGroup},
+		Aggregation: view.Count(),
+	}
+
+	httpClientCircuitBreakerState = stats.Int64(
+		"http/client/circuit_breaker_state",
+		"HTTP Client circuit breaker state (0: closed, 1: open, 2: half-open)",
+		stats.UnitDimensionless)
+
+	// HTTPClientCircuitBreakerStateView is an OpenCensus view that tracks
+	// the current state of HTTP client circuit breakers by pomerium service,
+	// destination and circuit breaker
+	HTTPClientCircuitBreakerStateView = &view.View{
+		Name:        httpClientCircuitBreakerState.Name(),
+		Measure:     httpClientCircuitBreakerState,
+		Description: httpClientCircuitBreakerState.Description(),
+		TagKeys:     []tag.Key{TagKeyService, TagKeyDestination, TagKeyCircuitBreaker},
+		Aggregation: view.LastValue(),
+	}
+
+	httpClientCacheHits = stats.Int64(
+		"http/client/cache_hits",
+		"HTTP Client requests served from cache",
+		stats.UnitDimensionless)
+	httpClientCacheMisses = stats.Int64(
+		"http/client/cache_misses",
+		"HTTP Client requests not found in cache",
+		stats.UnitDimensionless)
+
+	// HTTPClientCacheHitsView is an OpenCensus view that tracks HTTP client
+	// requests served from a route's cache by pomerium service and destination
+	HTTPClientCacheHitsView = &view.View{
+		Name:        "http/client/cache_hits_total",
+		Measure:     httpClientCacheHits,
+		Description: httpClientCacheHits.Description(),
+		TagKeys:     []tag.Key{TagKeyService, TagKeyDestination},
+		Aggregation: view.Count(),
+	}
+
+	// HTTPClientCacheMissesView is an OpenCensus view that tracks cacheable
+	// HTTP client requests not found in a route's cache by pomerium service
+	// and destination
+	HTTPClientCacheMissesView = &view.View{
+		Name:        "http/client/cache_misses_total",
+		Measure:     httpClientCacheMisses,
+		Description: httpClientCacheMisses.Description(),
+		TagKeys:     []tag.Key{TagKeyService, TagKeyDestination},
+		Aggregation: view.Count(),
+	}
 )
 
 // HTTPMetricsHandler creates a metrics middleware for incoming HTTP requests
@@ -154,3 +237,62 @@ func HTTPMetricsRoundTripper(service string, destination string) func(next http.
 		})
 	}
 }
+
+// WithDestinationGroup returns a context that labels the metrics of outbound
+// HTTP requests made with it by the given destination group.
+func WithDestinationGroup(ctx context.Context, group string) context.Context {
+	ctx, err := tag.New(ctx, tag.Upsert(TagKeyDestinationGroup, group))
+	if err != nil {
+		log.Warn().Err(err).Str("context", "WithDestinationGroup").Msg("telemetry/metrics: failed to create metrics tag")
+	}
+	return ctx
+}
+
+// RecordHTTPClientRetry records an outbound HTTP request being retried. Tags
+// are taken from the context, as set by HTTPMetricsRoundTripper.
+func RecordHTTPClientRetry(ctx context.Context) {
+	stats.Record(ctx, httpClientRetries.M(1))
+}
+
+// RecordHTTPClientRetriesExhausted records an outbound HTTP request that
+// failed after exhausting all of its retries. Tags are taken from the
+// context, as set by HTTPMetricsRoundTripper.
+func RecordHTTPClientRetriesExhausted(ctx context.Context) {
+	stats.Record(ctx, httpClientRetriesExhausted.M(1))
+}
+
+// SetHTTPClientCircuitBreakerState records the state of a circuit breaker
+// for a given service and destination, where 0 is closed, 1 is open and 2
+// is half-open. As a destination may have several breakers, with different
+// settings, breaker identifies which one it is.
+func SetHTTPClientCircuitBreakerState(service, destination, breaker string, state int64) {
+	if err := stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Insert(TagKeyService, service), tag.Insert(TagKeyDestination, destination), tag.Insert(TagKeyCircuitBreaker, breaker)},
+		httpClientCircuitBreakerState.M(state),
+	); err != nil {
+		log.Error().Err(err).Msg("telemetry/metrics: failed to record circuit breaker state")
+	}
+}
+
+// RecordHTTPClientCacheHit records an outbound HTTP request served from a
+// route's cache for a given service and destination.
+func RecordHTTPClientCacheHit(service, destination string) {
+	recordHTTPClientCache(service, destination, httpClientCacheHits)
+}
+
+// RecordHTTPClientCacheMiss records a cacheable outbound HTTP request not
+// found in a route's cache for a given service and destination.
+func RecordHTTPClientCacheMiss(service, destination string) {
+	recordHTTPClientCache(service, destination, httpClientCacheMisses)
+}
+
+func recordHTTPClientCache(service, destination string, m *stats.Int64Measure) {
+	if err := stats.RecordWithTags(
+		context.Background(),
+		[]tag.Mutator{tag.Insert(TagKeyService, service), tag.Insert(TagKeyDestination, destination)},
+		m.M(1),
+	); err != nil {
+		log.Error().Err(err).Msg("telemetry/metrics: failed to record cache lookup")
+	}
+}
@@ -137,6 +137,7 @@ func Test_HTTPMetricsRoundTripper(t *testing.T) {
 		name                          string
 		url                           string
 		verb                          string
+		group                         string
 		wanthttpClientRequestSize     string
 		wanthttpClientResponseSize    string
 		wanthttpClientRequestDuration string
@@ -169,6 +170,16 @@ func Test_HTTPMetricsRoundTripper(t *testing.T) {
 			wanthttpClientRequestDuration: "{ { {destination test_destination}{host test.local}{http.status 404}{http_method POST}{service test_service} }&{1",
 			wanthttpClientRequestCount:    "{ { {destination test_destination}{host test.local}{http.status 404}{http_method POST}{service test_service} }&{1",
 		},
+		{
+			name:                          "destination group",
+			url:                           "http://test.local/good",
+			verb:                          "GET",
+			group:                         "canary",
+			wanthttpClientRequestSize:     "{ { {destination test_destination}{destination_group canary}{host test.local}{http.status 200}{http_method GET}{service test_service} }&{1 5 5 5 0 [0 1 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0]",
+			wanthttpClientResponseSize:    "{ { {destination test_destination}{destination_group canary}{host test.local}{http.status 200}{http_method GET}{service test_service} }&{1 5 5 5 0 [0 1 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0]",
+			wanthttpClientRequestDuration: "{ { {destination test_destination}{destination_group canary}{host test.local}{http.status 200}{http_method GET}{service test_service} }&{1",
+			wanthttpClientRequestCount:    "{ { {destination test_destination}{destination_group canary}{host test.local}{http.status 200}{http_method GET}{service test_service} }&{1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -176,6 +187,9 @@ func Test_HTTPMetricsRoundTripper(t *testing.T) {
 			view.Register(HTTPClientRequestCountView, HTTPClientRequestDurationView, HTTPClientResponseSizeView, HTTPClientRequestSizeView)
 
 			req, _ := http.NewRequest(tt.verb, tt.url, new(bytes.Buffer))
+			if tt.group != "" {
+				req = req.WithContext(WithDestinationGroup(req.Context(), tt.group))
+			}
 			resp, err := client.Do(req)
 			// must be done to record()
 			ioutil.ReadAll(resp.Body)
@@ -196,3 +210,49 @@ func Test_HTTPMetricsRoundTripper(t *testing.T) {
 		t.Error("Transport error not surfaced properly")
 	}
 }
+
+func Test_RecordHTTPClientRetry(t *testing.T) {
+	view.Unregister(HTTPClientRetriesView, HTTPClientRetriesExhaustedView)
+	view.Register(HTTPClientRetriesView, HTTPClientRetriesExhaustedView)
+
+	retrier := func(next http.RoundTripper) http.RoundTripper {
+		return tripper.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			RecordHTTPClientRetry(r.Context())
+			RecordHTTPClientRetry(r.Context())
+			RecordHTTPClientRetriesExhausted(r.Context())
+			return next.RoundTrip(r)
+		})
+	}
+	chain := tripper.NewChain(HTTPMetricsRoundTripper("test_service", "test_destination"), retrier)
+	client := http.Client{Transport: chain.Then(newTestTransport())}
+	req, _ := http.NewRequest("GET", "http://test.local/good", new(bytes.Buffer))
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ioutil.ReadAll(resp.Body)
+
+	testDataRetrieval(HTTPClientRetriesView, t, "{ { {destination test_destination}{host test.local}{http_method GET}{service test_service} }&{2")
+	testDataRetrieval(HTTPClientRetriesExhaustedView, t, "{ { {destination test_destination}{host test.local}{http_method GET}{service test_service} }&{1")
+}
+
+func Test_RecordHTTPClientCache(t *testing.T) {
+	view.Unregister(HTTPClientCacheHitsView, HTTPClientCacheMissesView)
+	view.Register(HTTPClientCacheHitsView, HTTPClientCacheMissesView)
+
+	RecordHTTPClientCacheHit("test_service", "test_destination")
+	RecordHTTPClientCacheHit("test_service", "test_destination")
+	RecordHTTPClientCacheMiss("test_service", "test_destination")
+
+	testDataRetrieval(HTTPClientCacheHitsView, t, "{ { {destination test_destination}{service test_service} }&{2")
+	testDataRetrieval(HTTPClientCacheMissesView, t, "{ { {destination test_destination}{service test_service} }&{1")
+}
+
+func Test_SetHTTPClientCircuitBreakerState(t *testing.T) {
+	view.Unregister(HTTPClientCircuitBreakerStateView)
+	view.Register(HTTPClientCircuitBreakerStateView)
+
+	SetHTTPClientCircuitBreakerState("test_service", "test_destination", "test_breaker", 1)
+
+	testDataRetrieval(HTTPClientCircuitBreakerStateView, t, "{ { {circuit_breaker test_breaker}{destination test_destination}{service test_service} }&{1")
+}
@@ -14,6 +14,8 @@ const (
 	QuerySessionEncrypted  = "pomerium_session_encrypted"
 	QueryRedirectURI       = "pomerium_redirect_uri"
 	QueryRefreshToken      = "pomerium_refresh_token"
+	QueryForwardTokens     = "pomerium_forward_tokens"
+	QuerySessionTokens     = "pomerium_session_tokens"
 )
 
 // URL signature based query params used for verifying the authenticity of a URL.
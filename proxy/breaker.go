@@ -0,0 +1,235 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+// errCircuitOpen is returned, without contacting the upstream, for requests
+// made while a circuit breaker is open.
+var errCircuitOpen = errors.New("proxy: circuit breaker is open")
+
+type circuitState int64
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// circuitBreakerRoundTripper returns a tripper that fails requests to an
+// upstream fast once it has tripped the circuit breaker.
+func circuitBreakerRoundTripper(cb *circuitBreaker) tripper.Constructor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return tripper.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return cb.roundTrip(next, req)
+		})
+	}
+}
+
+type circuitBreakerKey struct {
+	destination string
+	settings    config.CircuitBreaker
+}
+
+// circuitBreakers are the proxy's circuit breakers. Routes to the same
+// destination, with the same settings, share a breaker, and breakers are kept
+// across reloads so that an open breaker stays open.
+type circuitBreakers struct {
+	mu       sync.Mutex
+	breakers map[circuitBreakerKey]*circuitBreaker
+	// loading are the breakers used by the policies being loaded
+	loading map[circuitBreakerKey]*circuitBreaker
+}
+
+func newCircuitBreakers() *circuitBreakers {
+	return &circuitBreakers{breakers: make(map[circuitBreakerKey]*circuitBreaker)}
+}
+
+// load starts loading a new set of policies.
+func (cbs *circuitBreakers) load() {
+	cbs.mu.Lock()
+	cbs.loading = make(map[circuitBreakerKey]*circuitBreaker)
+	cbs.mu.Unlock()
+}
+
+// get returns the circuit breaker for the destination and settings, creating
+// it if no route has used it before.
+func (cbs *circuitBreakers) get(destination string, settings config.CircuitBreaker) *circuitBreaker {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+
+	key := circuitBreakerKey{destination: destination, settings: settings}
+	cb, ok := cbs.breakers[key]
+	if !ok {
+		cb = newCircuitBreaker(destination, settings)
+		cbs.breakers[key] = cb
+	}
+	if cbs.loading != nil {
+		cbs.loading[key] = cb
+	}
+	return cb
+}
+
+// loaded discards the breakers no longer used once policies are loaded.
+func (cbs *circuitBreakers) loaded() {
+	cbs.mu.Lock()
+	if cbs.loading != nil {
+		cbs.breakers, cbs.loading = cbs.loading, nil
+	}
+	cbs.mu.Unlock()
+}
+
+// circuitBreaker tracks the health of a single upstream.
+type circuitBreaker struct {
+	destination string
+	settings    config.CircuitBreaker
+	// name tells apart the breakers of a destination in logs and metrics
+	name string
+	now  func() time.Time
+
+	mu       sync.Mutex
+	state    circuitState
+	openedAt time.Time
+	// generation is incremented on every state change so that the outcome of
+	// requests started in a previous state is ignored
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	inflight    int
+	trial       bool
+}
+
+func newCircuitBreaker(destination string, settings config.CircuitBreaker) *circuitBreaker {
+	cb := &circuitBreaker{destination: destination, settings: settings, name: circuitBreakerName(settings), now: time.Now}
+	metrics.SetHTTPClientCircuitBreakerState("proxy", destination, cb.name, int64(circuitClosed))
+	return cb
+}
+
+// circuitBreakerName describes a breaker's settings, which, with its
+// destination, identify it.
+func circuitBreakerName(settings config.CircuitBreaker) string {
+	return fmt.Sprintf("error_rate_threshold=%g,min_requests=%d,interval=%s,max_concurrent_requests=%d,cooldown=%s",
+		settings.ErrorRateThreshold, settings.MinRequests, settings.Interval, settings.MaxConcurrentRequests, settings.Cooldown)
+}
+
+func (cb *circuitBreaker) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	generation, err := cb.allow()
+	if err != nil {
+		return nil, err
+	}
+	res, err := next.RoundTrip(req)
+	cb.record(generation, err != nil || res.StatusCode >= http.StatusInternalServerError)
+	// upgraded connections are long lived, and are not counted as in flight
+	if err != nil || res.StatusCode == http.StatusSwitchingProtocols {
+		cb.release()
+		return res, err
+	}
+	res.Body = &releaseReadCloser{ReadCloser: res.Body, release: cb.release}
+	return res, nil
+}
+
+// allow reports whether a request may be made to the upstream and, if so,
+// counts it as in flight.
+func (cb *circuitBreaker) allow() (uint64, error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	now := cb.now()
+	switch cb.state {
+	case circuitOpen:
+		if now.Sub(cb.openedAt) < cb.settings.Cooldown {
+			return 0, errCircuitOpen
+		}
+		// let a single trial request through to test the upstream
+		cb.setState(circuitHalfOpen, now)
+		cb.trial = true
+	case circuitHalfOpen:
+		if cb.trial {
+			return 0, errCircuitOpen
+		}
+		cb.trial = true
+	default:
+		if cb.settings.MaxConcurrentRequests > 0 && cb.inflight >= cb.settings.MaxConcurrentRequests {
+			cb.setState(circuitOpen, now)
+			return 0, errCircuitOpen
+		}
+		if now.Sub(cb.windowStart) >= cb.settings.Interval {
+			cb.windowStart, cb.requests, cb.failures = now, 0, 0
+		}
+	}
+	cb.inflight++
+	return cb.generation, nil
+}
+
+// record updates the breaker's state with the outcome of a request.
+func (cb *circuitBreaker) record(generation uint64, failed bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return
+	}
+	now := cb.now()
+	switch cb.state {
+	case circuitHalfOpen:
+		if failed {
+			cb.setState(circuitOpen, now)
+		} else {
+			cb.setState(circuitClosed, now)
+		}
+	case circuitClosed:
+		cb.requests++
+		if failed {
+			cb.failures++
+		}
+		if cb.settings.ErrorRateThreshold > 0 &&
+			cb.requests >= cb.settings.MinRequests &&
+			float64(cb.failures)/float64(cb.requests) >= cb.settings.ErrorRateThreshold {
+			cb.setState(circuitOpen, now)
+		}
+	}
+}
+
+// release stops counting a request as in flight.
+func (cb *circuitBreaker) release() {
+	cb.mu.Lock()
+	cb.inflight--
+	cb.mu.Unlock()
+}
+
+// setState must be called with the lock held.
+func (cb *circuitBreaker) setState(state circuitState, now time.Time) {
+	cb.state = state
+	cb.generation++
+	cb.trial = false
+	cb.windowStart, cb.requests, cb.failures = now, 0, 0
+	if state == circuitOpen {
+		cb.openedAt = now
+		log.Warn().Str("destination", cb.destination).Str("circuit_breaker", cb.name).Msg("proxy: circuit breaker opened")
+	}
+	metrics.SetHTTPClientCircuitBreakerState("proxy", cb.destination, cb.name, int64(state))
+}
+
+// releaseReadCloser calls release, once, when its ReadCloser is closed.
+type releaseReadCloser struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (r *releaseReadCloser) Close() error {
+	err := r.ReadCloser.Close()
+	r.once.Do(r.release)
+	return err
+}
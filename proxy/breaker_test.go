@@ -0,0 +1,175 @@
+package proxy
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Parallel()
+	settings := config.CircuitBreaker{ErrorRateThreshold: 0.5, MinRequests: 4, Cooldown: time.Minute}
+	if err := settings.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	cb := newCircuitBreaker("upstream.example", settings)
+	cb.now = func() time.Time { return now }
+
+	status := http.StatusInternalServerError
+	next := func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+	}
+	do := func() error {
+		res, err := cb.roundTrip(tripper.RoundTripperFunc(next), httptest.NewRequest(http.MethodGet, "/", nil))
+		if err == nil {
+			res.Body.Close()
+		}
+		return err
+	}
+
+	for i := 0; i < 4; i++ {
+		if err := do(); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i, err)
+		}
+	}
+	if err := do(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("want open circuit, got %v", err)
+	}
+
+	// after the cooldown, a failed trial request re-opens the breaker
+	now = now.Add(time.Minute)
+	if err := do(); err != nil {
+		t.Fatalf("trial request: unexpected error %v", err)
+	}
+	if err := do(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("want open circuit after failed trial, got %v", err)
+	}
+
+	// a successful trial request closes it
+	now = now.Add(time.Minute)
+	status = http.StatusOK
+	for i := 0; i < 10; i++ {
+		if err := do(); err != nil {
+			t.Fatalf("request %d: unexpected error %v", i, err)
+		}
+	}
+	if cb.inflight != 0 {
+		t.Errorf("want no requests in flight, got %d", cb.inflight)
+	}
+}
+
+func TestCircuitBreaker_HalfOpenSingleTrial(t *testing.T) {
+	t.Parallel()
+	settings := config.CircuitBreaker{MaxConcurrentRequests: 1, Cooldown: time.Minute}
+	if err := settings.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	cb := newCircuitBreaker("upstream.example", settings)
+	cb.now = func() time.Time { return now }
+
+	if _, err := cb.allow(); err != nil {
+		t.Fatal(err)
+	}
+	// the second concurrent request trips the breaker
+	if _, err := cb.allow(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("want open circuit, got %v", err)
+	}
+	cb.release()
+
+	now = now.Add(time.Minute)
+	generation, err := cb.allow()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cb.allow(); !errors.Is(err, errCircuitOpen) {
+		t.Fatalf("want a single trial request, got %v", err)
+	}
+	cb.record(generation, false)
+	cb.release()
+	if cb.state != circuitClosed {
+		t.Errorf("want closed circuit, got %d", cb.state)
+	}
+}
+
+func TestCircuitBreakerRoundTripper(t *testing.T) {
+	t.Parallel()
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer backend.Close()
+
+	settings := config.CircuitBreaker{ErrorRateThreshold: 1, MinRequests: 1}
+	if err := settings.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	client := http.Client{Transport: circuitBreakerRoundTripper(newCircuitBreaker("upstream.example", settings))(http.DefaultTransport)}
+	res, err := client.Get(backend.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if _, err := client.Get(backend.URL); !errors.Is(err, errCircuitOpen) {
+		t.Errorf("want open circuit, got %v", err)
+	}
+}
+
+func TestProxy_circuitBreakers(t *testing.T) {
+	t.Parallel()
+	opts := testOptions(t)
+	breaker := config.CircuitBreaker{ErrorRateThreshold: 0.5}
+	opts.Policies = []config.Policy{
+		{From: "https://a.example", To: "https://upstream.internal", CircuitBreaker: breaker},
+		{From: "https://b.example", To: "https://upstream.internal", CircuitBreaker: breaker},
+	}
+	p, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// routes to the same upstream share its breaker
+	onlyBreaker := func() *circuitBreaker {
+		t.Helper()
+		if len(p.circuitBreakers.breakers) != 1 {
+			t.Fatalf("got %d breakers, want 1", len(p.circuitBreakers.breakers))
+		}
+		for _, cb := range p.circuitBreakers.breakers {
+			return cb
+		}
+		return nil
+	}
+	cb := onlyBreaker()
+	cb.mu.Lock()
+	cb.setState(circuitOpen, time.Now())
+	cb.mu.Unlock()
+
+	// an open breaker stays open across reloads
+	if err := p.UpdatePolicies(&opts); err != nil {
+		t.Fatal(err)
+	}
+	if got := onlyBreaker(); got != cb || got.state != circuitOpen {
+		t.Errorf("reload replaced the open breaker")
+	}
+
+	// breakers no longer used are discarded
+	opts.Policies[0].CircuitBreaker.ErrorRateThreshold = 0.9
+	opts.Policies[1].CircuitBreaker.ErrorRateThreshold = 0.9
+	if err := p.UpdatePolicies(&opts); err != nil {
+		t.Fatal(err)
+	}
+	got := onlyBreaker()
+	if got == cb {
+		t.Errorf("reload kept the breaker with the old settings")
+	}
+	// and breakers of the same destination are told apart in metrics
+	if got.name == cb.name {
+		t.Errorf("breakers with different settings have the same name %q", got.name)
+	}
+}
@@ -0,0 +1,365 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"bytes"
+	"container/list"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/textproto"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+// cacheableStatusCodes are the status codes whose responses may be stored.
+//
+// https://tools.ietf.org/html/rfc7231#section-6.1
+var cacheableStatusCodes = map[int]bool{
+	http.StatusOK:                   true,
+	http.StatusNonAuthoritativeInfo: true,
+	http.StatusNoContent:            true,
+	http.StatusMultipleChoices:      true,
+	http.StatusMovedPermanently:     true,
+	http.StatusPermanentRedirect:    true,
+	http.StatusNotFound:             true,
+	http.StatusMethodNotAllowed:     true,
+	http.StatusGone:                 true,
+	http.StatusRequestURITooLong:    true,
+	http.StatusNotImplemented:       true,
+}
+
+// cacheRoundTripper returns a tripper that serves fresh responses from a
+// route's in-memory cache, and stores cacheable upstream responses as they
+// are read.
+func cacheRoundTripper(destination string, settings config.Cache) tripper.Constructor {
+	c := newResponseCache(destination, settings)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return tripper.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return c.roundTrip(next, req)
+		})
+	}
+}
+
+// responseCache is a size limited, least recently used, cache of upstream
+// responses.
+//
+// Responses are keyed by the user's identity, so that they are never shared
+// across users, unless the upstream marks them as public.
+type responseCache struct {
+	destination string
+	settings    config.Cache
+	now         func() time.Time
+
+	mu   sync.Mutex
+	size int64
+	// lru holds *cacheEntry values, the most recently used at the front
+	lru     *list.List
+	entries map[string]*list.Element
+	// vary holds the request headers a response varies by, by primary key
+	vary map[string][]string
+}
+
+type cacheEntry struct {
+	key     string
+	status  int
+	header  http.Header
+	body    []byte
+	stored  time.Time
+	age     time.Duration
+	expires time.Time
+}
+
+func newResponseCache(destination string, settings config.Cache) *responseCache {
+	return &responseCache{
+		destination: destination,
+		settings:    settings,
+		now:         time.Now,
+		lru:         list.New(),
+		entries:     make(map[string]*list.Element),
+		vary:        make(map[string][]string),
+	}
+}
+
+func (c *responseCache) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || req.Header.Get("Range") != "" || req.Header.Get("Upgrade") != "" {
+		return next.RoundTrip(req)
+	}
+	identity := cacheIdentity(req)
+	reqCC := parseCacheControl(req.Header)
+	_, noCache := reqCC["no-cache"]
+	if maxAge, ok := reqCC["max-age"]; ok && maxAge == "0" {
+		noCache = true
+	}
+	if req.Header.Get("Pragma") == "no-cache" {
+		noCache = true
+	}
+	if !noCache {
+		if res := c.lookup(req, identity); res != nil {
+			metrics.RecordHTTPClientCacheHit("proxy", c.destination)
+			return res, nil
+		}
+	}
+	metrics.RecordHTTPClientCacheMiss("proxy", c.destination)
+
+	res, err := next.RoundTrip(req)
+	if err != nil {
+		return res, err
+	}
+	if _, noStore := reqCC["no-store"]; !noStore {
+		c.storeOnRead(req, identity, res)
+	}
+	return res, nil
+}
+
+// lookup returns a fresh response to the request, shared or specific to the
+// user, if there is one.
+func (c *responseCache) lookup(req *http.Request, identity string) *http.Response {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	identities := []string{""}
+	if identity != "" {
+		identities = append(identities, identity)
+	}
+	now := c.now()
+	for _, id := range identities {
+		primary := primaryCacheKey(id, req)
+		el, ok := c.entries[cacheKey(primary, c.vary[primary], req)]
+		if !ok {
+			continue
+		}
+		e := el.Value.(*cacheEntry)
+		if !now.Before(e.expires) {
+			c.remove(el)
+			continue
+		}
+		c.lru.MoveToFront(el)
+		return e.response(req, now)
+	}
+	return nil
+}
+
+// storeOnRead stores the upstream's response once its body has been read, if
+// it is cacheable.
+func (c *responseCache) storeOnRead(req *http.Request, identity string, res *http.Response) {
+	if !cacheableStatusCodes[res.StatusCode] || res.Header.Get("Set-Cookie") != "" {
+		return
+	}
+	if res.ContentLength > c.settings.MaxEntryBytes {
+		return
+	}
+	cc := parseCacheControl(res.Header)
+	for _, directive := range []string{"no-store", "no-cache", "private"} {
+		if _, ok := cc[directive]; ok {
+			return
+		}
+	}
+	var vary []string
+	for _, line := range res.Header["Vary"] {
+		for _, name := range strings.Split(line, ",") {
+			name = textproto.CanonicalMIMEHeaderKey(strings.TrimSpace(name))
+			if name == "*" {
+				return
+			}
+			if name != "" {
+				vary = append(vary, name)
+			}
+		}
+	}
+	now := c.now()
+	lifetime := freshnessLifetime(res.Header, cc, now)
+	age := parseSeconds(res.Header.Get("Age"))
+	if lifetime <= age {
+		return
+	}
+
+	_, public := cc["public"]
+	if public {
+		identity = ""
+	} else if identity == "" && (req.Header.Get("Authorization") != "" || req.Header.Get("Cookie") != "") {
+		// without a session, credentials are the only sign of a user
+		// specific response
+		return
+	}
+	primary := primaryCacheKey(identity, req)
+	e := &cacheEntry{
+		key:     cacheKey(primary, vary, req),
+		status:  res.StatusCode,
+		header:  res.Header.Clone(),
+		stored:  now,
+		age:     age,
+		expires: now.Add(lifetime - age),
+	}
+	res.Body = &cacheReadCloser{
+		ReadCloser: res.Body,
+		limit:      c.settings.MaxEntryBytes,
+		store: func(body []byte) {
+			e.body = body
+			c.store(primary, vary, e)
+		},
+	}
+}
+
+func (c *responseCache) store(primary string, vary []string, e *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.vary[primary] = vary
+	if el, ok := c.entries[e.key]; ok {
+		c.remove(el)
+	}
+	c.entries[e.key] = c.lru.PushFront(e)
+	c.size += e.size()
+	for c.size > c.settings.MaxSizeBytes {
+		c.remove(c.lru.Back())
+	}
+}
+
+// remove must be called with the lock held.
+func (c *responseCache) remove(el *list.Element) {
+	e := c.lru.Remove(el).(*cacheEntry)
+	delete(c.entries, e.key)
+	c.size -= e.size()
+}
+
+func (e *cacheEntry) size() int64 {
+	n := len(e.key) + len(e.body)
+	for k, vs := range e.header {
+		for _, v := range vs {
+			n += len(k) + len(v)
+		}
+	}
+	return int64(n)
+}
+
+func (e *cacheEntry) response(req *http.Request, now time.Time) *http.Response {
+	header := e.header.Clone()
+	header.Set("Age", strconv.FormatInt(int64((e.age+now.Sub(e.stored))/time.Second), 10))
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.status, http.StatusText(e.status)),
+		StatusCode:    e.status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(e.body)),
+		ContentLength: int64(len(e.body)),
+		Request:       req,
+	}
+}
+
+// cacheIdentity returns the identity, including any impersonation, of the
+// user making a request, or an empty string if there is no session.
+func cacheIdentity(req *http.Request) string {
+	s, err := sessions.FromContext(req.Context())
+	if s == nil || err != nil {
+		return ""
+	}
+	return strings.Join([]string{s.Subject, s.ImpersonateEmail, strings.Join(s.ImpersonateGroups, ",")}, "\x00")
+}
+
+func primaryCacheKey(identity string, req *http.Request) string {
+	return identity + "\x00" + req.URL.String()
+}
+
+// cacheKey adds the values of the request headers a response varies by to a
+// primary key.
+func cacheKey(primary string, vary []string, req *http.Request) string {
+	var sb strings.Builder
+	sb.WriteString(primary)
+	for _, name := range vary {
+		sb.WriteString("\x00")
+		sb.WriteString(name)
+		sb.WriteString("=")
+		sb.WriteString(strings.Join(req.Header[name], ","))
+	}
+	return sb.String()
+}
+
+// parseCacheControl returns a header's Cache-Control directives, keyed by
+// their lower case name.
+func parseCacheControl(h http.Header) map[string]string {
+	cc := make(map[string]string)
+	for _, line := range h["Cache-Control"] {
+		for _, part := range strings.Split(line, ",") {
+			name, value := part, ""
+			if i := strings.Index(part, "="); i >= 0 {
+				name, value = part[:i], strings.Trim(strings.TrimSpace(part[i+1:]), `"`)
+			}
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name != "" {
+				cc[name] = value
+			}
+		}
+	}
+	return cc
+}
+
+// freshnessLifetime returns how long a response is fresh for after it was
+// generated by the upstream.
+//
+// https://tools.ietf.org/html/rfc7234#section-4.2.1
+func freshnessLifetime(h http.Header, cc map[string]string, now time.Time) time.Duration {
+	if v, ok := cc["s-maxage"]; ok {
+		return parseSeconds(v)
+	}
+	if v, ok := cc["max-age"]; ok {
+		return parseSeconds(v)
+	}
+	if v := h.Get("Expires"); v != "" {
+		expires, err := http.ParseTime(v)
+		if err != nil {
+			return 0
+		}
+		date, err := http.ParseTime(h.Get("Date"))
+		if err != nil {
+			date = now
+		}
+		return expires.Sub(date)
+	}
+	return 0
+}
+
+func parseSeconds(v string) time.Duration {
+	n, err := strconv.ParseInt(v, 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+	return time.Duration(n) * time.Second
+}
+
+// cacheReadCloser buffers a response body as it is read, and stores it once
+// it has been read in full, unless it exceeds the limit.
+type cacheReadCloser struct {
+	io.ReadCloser
+	buf      bytes.Buffer
+	limit    int64
+	overflow bool
+	stored   bool
+	store    func(body []byte)
+}
+
+func (r *cacheReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if !r.overflow {
+		if int64(r.buf.Len()+n) > r.limit {
+			r.overflow = true
+			r.buf = bytes.Buffer{}
+		} else {
+			r.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !r.overflow && !r.stored {
+		r.stored = true
+		r.store(r.buf.Bytes())
+	}
+	return n, err
+}
@@ -0,0 +1,113 @@
+package proxy
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/sessions"
+)
+
+func TestResponseCache(t *testing.T) {
+	t.Parallel()
+	alice := &sessions.State{Subject: "alice"}
+	bob := &sessions.State{Subject: "bob"}
+	aliceAsBob := &sessions.State{Subject: "alice", ImpersonateEmail: "bob@example.com"}
+
+	type request struct {
+		session *sessions.State
+		header  http.Header
+		advance time.Duration
+	}
+	tests := []struct {
+		name         string
+		header       http.Header
+		body         string
+		requests     []request
+		wantUpstream int32
+	}{
+		{"max age", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 1},
+		{"s-maxage", http.Header{"Cache-Control": {"s-maxage=60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 1},
+		{"expires", http.Header{"Expires": {time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 1},
+		{"expired", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, time.Minute}}, 2},
+		{"already stale", http.Header{"Cache-Control": {"max-age=60"}, "Age": {"60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"no freshness", nil, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"no-store", http.Header{"Cache-Control": {"no-store, max-age=60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"no-cache", http.Header{"Cache-Control": {"no-cache, max-age=60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"private", http.Header{"Cache-Control": {"private, max-age=60"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"set cookie", http.Header{"Cache-Control": {"max-age=60"}, "Set-Cookie": {"a=b"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"not shared across users", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{alice, nil, 0}, {bob, nil, 0}}, 2},
+		{"not shared when impersonating", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{alice, nil, 0}, {aliceAsBob, nil, 0}}, 2},
+		{"public shared across users", http.Header{"Cache-Control": {"public, max-age=60"}}, "ok", []request{{alice, nil, 0}, {bob, nil, 0}}, 1},
+		{"anonymous", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{nil, nil, 0}, {nil, nil, 0}}, 1},
+		{"anonymous with credentials", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{nil, http.Header{"Cookie": {"a=b"}}, 0}, {nil, http.Header{"Cookie": {"a=b"}}, 0}}, 2},
+		{"vary", http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"Accept-Language"}}, "ok", []request{{alice, http.Header{"Accept-Language": {"en"}}, 0}, {alice, http.Header{"Accept-Language": {"fr"}}, 0}, {alice, http.Header{"Accept-Language": {"en"}}, 0}}, 2},
+		{"vary star", http.Header{"Cache-Control": {"max-age=60"}, "Vary": {"*"}}, "ok", []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+		{"request no-cache", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{alice, nil, 0}, {alice, http.Header{"Cache-Control": {"no-cache"}}, 0}}, 2},
+		{"request no-store", http.Header{"Cache-Control": {"max-age=60"}}, "ok", []request{{alice, http.Header{"Cache-Control": {"no-store"}}, 0}, {alice, nil, 0}}, 2},
+		{"entry too large", http.Header{"Cache-Control": {"max-age=60"}}, strings.Repeat("a", 11), []request{{alice, nil, 0}, {alice, nil, 0}}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var upstream int32
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&upstream, 1)
+				for k, vs := range tt.header {
+					w.Header()[k] = vs
+				}
+				fmt.Fprint(w, tt.body)
+			}))
+			defer backend.Close()
+
+			now := time.Now()
+			c := newResponseCache("upstream.example", config.Cache{MaxSizeBytes: 1 << 20, MaxEntryBytes: 10})
+			c.now = func() time.Time { return now }
+			for i, rr := range tt.requests {
+				now = now.Add(rr.advance)
+				req := httptest.NewRequest(http.MethodGet, backend.URL+"/assets/app.js", nil)
+				req.RequestURI = ""
+				for k, vs := range rr.header {
+					req.Header[k] = vs
+				}
+				if rr.session != nil {
+					req = req.WithContext(sessions.NewContext(req.Context(), rr.session, nil))
+				}
+				res, err := c.roundTrip(http.DefaultTransport, req)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body, _ := ioutil.ReadAll(res.Body)
+				res.Body.Close()
+				if string(body) != tt.body {
+					t.Errorf("request %d: body = %q, want %q", i, body, tt.body)
+				}
+			}
+			if got := atomic.LoadInt32(&upstream); got != tt.wantUpstream {
+				t.Errorf("upstream requests = %d, want %d", got, tt.wantUpstream)
+			}
+		})
+	}
+}
+
+func TestResponseCache_Evicts(t *testing.T) {
+	t.Parallel()
+	c := newResponseCache("upstream.example", config.Cache{MaxSizeBytes: 100, MaxEntryBytes: 100})
+	for i := 0; i < 10; i++ {
+		c.store(fmt.Sprint(i), nil, &cacheEntry{key: fmt.Sprint(i), body: make([]byte, 30), expires: time.Now().Add(time.Hour)})
+	}
+	if c.size > 100 {
+		t.Errorf("cache size = %d, want at most %d", c.size, 100)
+	}
+	if _, ok := c.entries["9"]; !ok {
+		t.Error("most recent entry was evicted")
+	}
+	if _, ok := c.entries["0"]; ok {
+		t.Error("least recent entry was not evicted")
+	}
+}
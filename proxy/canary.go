@@ -0,0 +1,102 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"hash/fnv"
+	"net/http"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/cryptutil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+)
+
+// destinationCookieMaxAge is how long users of public routes, who are
+// assigned a destination group by cookie, keep their assignment.
+const destinationCookieMaxAge = 365 * 24 * time.Hour
+
+// destinationGroups splits a route's users between weighted groups of
+// destinations. Users are assigned to a group by hashing their id, or, on
+// public routes, a random id kept in a cookie, so that they stay in the same
+// group across requests.
+type destinationGroups struct {
+	route      string
+	cookie     http.Cookie
+	groups     []destinationGroup
+	weightsSum uint64
+}
+
+type destinationGroup struct {
+	name    string
+	weight  uint64
+	handler http.Handler
+}
+
+// destinationGroupsHandler returns a handler that serves each request with
+// the handler of its user's group. handler is called with a copy of the
+// route for each group, with the group's destination as its only
+// destination, so that anything depending on the destination, such as the
+// upstream's JWT audience, is built for that group.
+func (p *Proxy) destinationGroupsHandler(policy *config.Policy, handler func(*config.Policy) (http.Handler, error)) (http.Handler, error) {
+	dg := &destinationGroups{
+		route: policy.Source.String() + policy.Prefix,
+		cookie: http.Cookie{
+			Name:     p.cookieOptions.Name + "_destination",
+			Path:     "/",
+			Secure:   p.cookieOptions.Secure,
+			HttpOnly: true,
+			MaxAge:   int(destinationCookieMaxAge.Seconds()),
+			SameSite: http.SameSiteLaxMode,
+		},
+	}
+	for _, g := range policy.DestinationGroups {
+		gp := *policy
+		gp.Destination = g.Destination
+		h, err := handler(&gp)
+		if err != nil {
+			return nil, err
+		}
+		dg.groups = append(dg.groups, destinationGroup{name: g.Name, weight: uint64(g.Weight), handler: h})
+		dg.weightsSum += uint64(g.Weight)
+	}
+	return dg, nil
+}
+
+func (dg *destinationGroups) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g := dg.assign(dg.userID(w, r))
+	g.handler.ServeHTTP(w, r.WithContext(metrics.WithDestinationGroup(r.Context(), g.name)))
+}
+
+// userID returns the id requests are assigned a group by: the session's
+// subject or, if there is no session, the id in the user's cookie. Users
+// without a cookie are given one.
+func (dg *destinationGroups) userID(w http.ResponseWriter, r *http.Request) string {
+	if s, err := sessions.FromContext(r.Context()); err == nil && s != nil && s.Subject != "" {
+		return s.Subject
+	}
+	if c, err := r.Cookie(dg.cookie.Name); err == nil && c.Value != "" {
+		return c.Value
+	}
+	c := dg.cookie
+	c.Value = cryptutil.NewRandomStringN(32)
+	http.SetCookie(w, &c)
+	return c.Value
+}
+
+// assign returns the group of the user with the given id. Groups take up
+// consecutive ranges of the hashed id space, in order, so that moving weight
+// to the last group, while keeping the same total, only moves users into it.
+func (dg *destinationGroups) assign(id string) *destinationGroup {
+	h := fnv.New64a()
+	h.Write([]byte(dg.route))
+	h.Write([]byte{0})
+	h.Write([]byte(id))
+	n := h.Sum64() % dg.weightsSum
+	for i := range dg.groups {
+		if n < dg.groups[i].weight {
+			return &dg.groups[i]
+		}
+		n -= dg.groups[i].weight
+	}
+	return &dg.groups[len(dg.groups)-1]
+}
@@ -0,0 +1,195 @@
+package proxy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/cryptutil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/proxy/clients"
+	"gopkg.in/square/go-jose.v2/jwt"
+)
+
+func TestDestinationGroups(t *testing.T) {
+	t.Parallel()
+	upstream := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprint(w, name)
+		}))
+	}
+	stable, canary := upstream("stable"), upstream("canary")
+	defer stable.Close()
+	defer canary.Close()
+
+	p, err := New(testOptions(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	policy := config.Policy{
+		From: "https://httpbin.corp.example",
+		DestinationGroups: []config.DestinationGroup{
+			{Name: "stable", To: stable.URL, Weight: 95},
+			{Name: "canary", To: canary.URL, Weight: 5},
+		},
+	}
+	if err := policy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	h, err := p.destinationGroupsHandler(&policy, func(gp *config.Policy) (http.Handler, error) {
+		return p.reverseProxy(gp), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serve := func(r *http.Request) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusOK)
+		}
+		return w
+	}
+	group := func(w *httptest.ResponseRecorder) string {
+		b, _ := ioutil.ReadAll(w.Body)
+		return string(b)
+	}
+	withSubject := func(subject string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, "https://httpbin.corp.example/", nil)
+		return r.WithContext(sessions.NewContext(r.Context(), &sessions.State{Subject: subject}, nil))
+	}
+
+	t.Run("sticky by subject", func(t *testing.T) {
+		want := group(serve(withSubject("user")))
+		for i := 0; i < 10; i++ {
+			if got := group(serve(withSubject("user"))); got != want {
+				t.Fatalf("group = %q, want %q", got, want)
+			}
+		}
+	})
+
+	t.Run("weighted", func(t *testing.T) {
+		dg := h.(*destinationGroups)
+		canaries := 0
+		for i := 0; i < 10000; i++ {
+			if dg.assign(fmt.Sprintf("user-%d", i)).name == "canary" {
+				canaries++
+			}
+		}
+		if canaries < 400 || canaries > 600 {
+			t.Errorf("assigned %d of 10000 users to canary, want about 500", canaries)
+		}
+	})
+
+	t.Run("sticky by cookie", func(t *testing.T) {
+		w := serve(httptest.NewRequest(http.MethodGet, "https://httpbin.corp.example/", nil))
+		cookies := w.Result().Cookies()
+		if len(cookies) != 1 || cookies[0].Name != "_pomerium_destination" {
+			t.Fatalf("cookies = %v, want a destination cookie", cookies)
+		}
+		want := group(w)
+		for i := 0; i < 10; i++ {
+			r := httptest.NewRequest(http.MethodGet, "https://httpbin.corp.example/", nil)
+			r.AddCookie(cookies[0])
+			w := serve(r)
+			if got := group(w); got != want {
+				t.Fatalf("group = %q, want %q", got, want)
+			}
+			if len(w.Result().Cookies()) != 0 {
+				t.Fatal("cookie set again")
+			}
+		}
+	})
+}
+
+func TestProxy_destinationGroups(t *testing.T) {
+	t.Parallel()
+	// the upstream requires a JWT, and is too slow for the route's timeout
+	// on /slow
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(HeaderJWT) == "" {
+			http.Error(w, "no jwt", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Path == "/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+	}))
+	defer upstream.Close()
+	// the same upstream, by two names
+	port := upstream.Listener.Addr().(*net.TCPAddr).Port
+	stable, canary := fmt.Sprintf("127.0.0.1:%d", port), fmt.Sprintf("localhost:%d", port)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey, err := cryptutil.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := testOptions(t)
+	opts.SigningKey = base64.StdEncoding.EncodeToString(pemKey)
+	opts.Policies = []config.Policy{{
+		From:            "https://httpbin.corp.example",
+		UpstreamTimeout: 50 * time.Millisecond,
+		DestinationGroups: []config.DestinationGroup{
+			{Name: "stable", To: "http://" + stable, Weight: 50},
+			{Name: "canary", To: "http://" + canary, Weight: 50},
+		},
+	}}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	p, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	p.AuthorizeClient = clients.MockAuthorize{AuthorizeResponse: true}
+
+	serve := func(subject, path string) *httptest.ResponseRecorder {
+		p.sessionLoaders = []sessions.SessionLoader{&sessions.MockSessionStore{Session: &sessions.State{Subject: subject, Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}}
+		if err := p.UpdateOptions(opts); err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://httpbin.corp.example"+path, nil))
+		return w
+	}
+	// each group's requests are signed, and time out with a message naming
+	// the group's own destination
+	seen := make(map[string]bool)
+	for i := 0; i < 20 && len(seen) < 2; i++ {
+		subject := fmt.Sprintf("user-%d", i)
+		if w := serve(subject, "/"); w.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body.String())
+		}
+		w := serve(subject, "/slow")
+		if w.Code != http.StatusServiceUnavailable {
+			t.Fatalf("status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+		}
+		switch {
+		case strings.Contains(w.Body.String(), stable+" timed out"):
+			seen[stable] = true
+		case strings.Contains(w.Body.String(), canary+" timed out"):
+			seen[canary] = true
+		default:
+			t.Fatalf("timeout message = %q, want one naming a group's destination", w.Body.String())
+		}
+	}
+	if len(seen) != 2 {
+		t.Errorf("timed out destinations = %v, want both groups", seen)
+	}
+}
@@ -0,0 +1,205 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+)
+
+// Compress compresses responses for clients that accept one of the allowed
+// encodings. Responses that are too small, of a media type that is not
+// allowed, or already encoded by the upstream, are written as is. Upgraded
+// connections (e.g. websockets) are never compressed.
+func Compress(settings config.Compression) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, span := trace.StartSpan(r.Context(), "proxy.Compress")
+			defer span.End()
+			encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), settings.Encodings)
+			if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+			cw := &compressResponseWriter{ResponseWriter: w, settings: settings, encoding: encoding}
+			defer cw.Close()
+			next.ServeHTTP(cw, r.WithContext(ctx))
+		})
+	}
+}
+
+// negotiateEncoding returns the first of the allowed encodings the client
+// accepts, or an empty string if there is none.
+//
+// https://tools.ietf.org/html/rfc7231#section-5.3.4
+func negotiateEncoding(acceptEncoding string, allowed []string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		params := strings.Split(part, ";")
+		coding := strings.ToLower(strings.TrimSpace(params[0]))
+		accepted[coding] = true
+		for _, param := range params[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				q, err := strconv.ParseFloat(param[2:], 64)
+				accepted[coding] = err == nil && q > 0
+			}
+		}
+	}
+	for _, encoding := range allowed {
+		if ok, found := accepted[encoding]; ok || (!found && accepted["*"]) {
+			return encoding
+		}
+	}
+	return ""
+}
+
+// compressResponseWriter buffers a response until it is known whether it
+// should be compressed: once its headers and at least the minimum size have
+// been written, the response is flushed, or the handler has returned.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	settings config.Compression
+	encoding string
+
+	status  int
+	decided bool
+	buf     []byte
+	encoder io.WriteCloser
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+	if !w.compressible() {
+		w.passthrough()
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.decided {
+		if w.encoder != nil {
+			return w.encoder.Write(p)
+		}
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.settings.MinSizeBytes {
+		if err := w.startEncoding(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush implements http.Flusher, so that streamed responses are sent as
+// they are written.
+func (w *compressResponseWriter) Flush() {
+	if w.status == 0 {
+		return
+	}
+	if !w.decided {
+		// a streamed response is likely to grow past the minimum size
+		w.startEncoding()
+	}
+	if f, ok := w.encoder.(interface{ Flush() error }); ok {
+		f.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Unwrap returns the underlying response writer.
+func (w *compressResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Close writes any response still buffered, and finishes compression.
+func (w *compressResponseWriter) Close() error {
+	if w.status == 0 {
+		return nil
+	}
+	if !w.decided {
+		// the response is smaller than the minimum size
+		return w.passthrough()
+	}
+	if w.encoder != nil {
+		return w.encoder.Close()
+	}
+	return nil
+}
+
+// compressible reports whether the response's headers allow it to be
+// compressed.
+func (w *compressResponseWriter) compressible() bool {
+	switch {
+	case w.status < http.StatusOK, w.status == http.StatusNoContent, w.status == http.StatusNotModified:
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || strings.Contains(h.Get("Cache-Control"), "no-transform") {
+		return false
+	}
+	if cl, err := strconv.Atoi(h.Get("Content-Length")); err == nil && cl < w.settings.MinSizeBytes {
+		return false
+	}
+	mediaType, _, err := mime.ParseMediaType(h.Get("Content-Type"))
+	if err != nil {
+		return false
+	}
+	for _, allowed := range w.settings.ContentTypes {
+		if allowed == mediaType || (strings.HasSuffix(allowed, "/*") && strings.HasPrefix(mediaType, allowed[:len(allowed)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+func (w *compressResponseWriter) passthrough() error {
+	w.decided = true
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *compressResponseWriter) startEncoding() error {
+	w.decided = true
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Encoding", w.encoding)
+	h.Add("Vary", "Accept-Encoding")
+	// the compressed representation is no longer byte for byte identical
+	if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		h.Set("ETag", "W/"+etag)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	switch w.encoding {
+	case "br":
+		w.encoder = brotli.NewWriter(w.ResponseWriter)
+	default:
+		w.encoder = gzip.NewWriter(w.ResponseWriter)
+	}
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.encoder.Write(w.buf)
+	w.buf = nil
+	return err
+}
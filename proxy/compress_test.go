@@ -0,0 +1,163 @@
+package proxy
+
+import (
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestCompress(t *testing.T) {
+	t.Parallel()
+	settings := config.Compression{Enabled: true}
+	if err := settings.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	large := strings.Repeat("a", 2048)
+
+	tests := []struct {
+		name           string
+		method         string
+		requestHeader  http.Header
+		status         int
+		responseHeader http.Header
+		body           string
+		wantEncoding   string
+	}{
+		{"gzip", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, large, "gzip"},
+		{"brotli", http.MethodGet, http.Header{"Accept-Encoding": {"gzip, br"}}, http.StatusOK, http.Header{"Content-Type": {"text/html; charset=utf-8"}}, large, "br"},
+		{"q values", http.MethodGet, http.Header{"Accept-Encoding": {"br;q=0, gzip;q=0.5"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, large, "gzip"},
+		{"wildcard", http.MethodGet, http.Header{"Accept-Encoding": {"*"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, large, "br"},
+		{"not accepted", http.MethodGet, http.Header{"Accept-Encoding": {"deflate"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, large, ""},
+		{"no accept encoding", http.MethodGet, nil, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, large, ""},
+		{"too small", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, "ok", ""},
+		{"content length too small", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}, "Content-Length": {"2"}}, "ok", ""},
+		{"content type not allowed", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusOK, http.Header{"Content-Type": {"image/png"}}, large, ""},
+		{"already encoded", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}, "Content-Encoding": {"identity"}}, large, "identity"},
+		{"no-transform", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}, "Cache-Control": {"no-transform"}}, large, ""},
+		{"upgrade", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}, "Upgrade": {"websocket"}}, http.StatusOK, http.Header{"Content-Type": {"text/html"}}, large, ""},
+		{"not found", http.MethodGet, http.Header{"Accept-Encoding": {"gzip"}}, http.StatusNotFound, http.Header{"Content-Type": {"text/html"}}, large, "gzip"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := Compress(settings)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, vs := range tt.responseHeader {
+					w.Header()[k] = vs
+				}
+				w.WriteHeader(tt.status)
+				io.WriteString(w, tt.body)
+			}))
+			r := httptest.NewRequest(tt.method, "/", nil)
+			for k, vs := range tt.requestHeader {
+				r.Header[k] = vs
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.status {
+				t.Errorf("Compress() status = %d, want %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Content-Encoding"); got != tt.wantEncoding {
+				t.Fatalf("Compress() Content-Encoding = %q, want %q", got, tt.wantEncoding)
+			}
+			var body io.Reader = w.Body
+			switch tt.wantEncoding {
+			case "gzip":
+				zr, err := gzip.NewReader(w.Body)
+				if err != nil {
+					t.Fatal(err)
+				}
+				body = zr
+			case "br":
+				body = brotli.NewReader(w.Body)
+			}
+			got, err := ioutil.ReadAll(body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.body {
+				t.Errorf("Compress() body = %q, want %q", got, tt.body)
+			}
+			if tt.wantEncoding == "gzip" || tt.wantEncoding == "br" {
+				if w.Header().Get("Content-Length") != "" {
+					t.Error("Compress() kept the uncompressed Content-Length")
+				}
+				if w.Header().Get("Vary") != "Accept-Encoding" {
+					t.Errorf("Compress() Vary = %q, want %q", w.Header().Get("Vary"), "Accept-Encoding")
+				}
+			}
+		})
+	}
+}
+
+func TestCompress_Flush(t *testing.T) {
+	t.Parallel()
+	settings := config.Compression{Enabled: true}
+	if err := settings.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	flushed := make(chan struct{})
+	done := make(chan struct{})
+	srv := httptest.NewServer(Compress(settings)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		io.WriteString(w, "event")
+		w.(http.Flusher).Flush()
+		close(flushed)
+		<-done
+	})))
+	defer srv.Close()
+	defer close(done)
+
+	req, _ := http.NewRequest(http.MethodGet, srv.URL, nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	res, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+	<-flushed
+	// a streamed event is readable before the response is complete
+	zr, err := gzip.NewReader(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	buf := make([]byte, len("event"))
+	if _, err := io.ReadFull(zr, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != "event" {
+		t.Errorf("Compress() streamed %q, want %q", buf, "event")
+	}
+}
+
+func Test_negotiateEncoding(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		acceptEncoding string
+		allowed        []string
+		want           string
+	}{
+		{"gzip, br", []string{"br", "gzip"}, "br"},
+		{"gzip, br", []string{"gzip", "br"}, "gzip"},
+		{"GZIP", []string{"br", "gzip"}, "gzip"},
+		{"br;q=0", []string{"br"}, ""},
+		{"*;q=0, gzip", []string{"br", "gzip"}, "gzip"},
+		{"*, br;q=0", []string{"br", "gzip"}, "gzip"},
+		{"deflate", []string{"br", "gzip"}, ""},
+		{"", []string{"br", "gzip"}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.acceptEncoding, func(t *testing.T) {
+			if got := negotiateEncoding(tt.acceptEncoding, tt.allowed); got != tt.want {
+				t.Errorf("negotiateEncoding() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+)
+
+// errorHandler is a reverse proxy error handler. Requests failed fast by an
+// open circuit breaker are a service unavailable error, and requests whose
+// streamed body exceeded the route's limit are a request entity too large
+// error. Any other error is a bad gateway.
+func errorHandler(w http.ResponseWriter, r *http.Request, err error) {
+	var status int
+	switch {
+	case errors.Is(err, errCircuitOpen):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, errRequestBodyTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	default:
+		log.FromRequest(r).Error().Err(err).Msg("proxy: upstream error")
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	httpErr := &httputil.HTTPError{Status: status, Err: err}
+	httpErr.ErrorResponse(w, r)
+}
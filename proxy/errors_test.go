@@ -0,0 +1,30 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_errorHandler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"circuit open", errCircuitOpen, http.StatusServiceUnavailable},
+		{"body too large", errRequestBodyTooLarge, http.StatusRequestEntityTooLarge},
+		{"other", errors.New("error"), http.StatusBadGateway},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			errorHandler(w, httptest.NewRequest(http.MethodGet, "/", nil), tt.err)
+			if w.Code != tt.wantStatus {
+				t.Errorf("errorHandler() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -1,11 +1,18 @@
 package proxy // import "github.com/pomerium/pomerium/proxy"
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"reflect"
+	"strings"
 
+	"github.com/gorilla/mux"
+
+	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/urlutil"
@@ -26,10 +33,43 @@ func (p *Proxy) registerFwdAuthHandlers() http.Handler {
 		HeadersRegexp(httputil.HeaderForwardedURI, urlutil.QuerySessionEncrypted)
 	r.Handle("/", p.Verify(false)).Queries("uri", "{uri}")
 	r.Handle("/verify", p.Verify(true)).Queries("uri", "{uri}")
+	// trusted proxies may instead describe the request to verify with headers
+	r.Handle("/", p.VerifyForwarded(false, "")).MatcherFunc(p.fromTrustedProxy)
+	r.Handle("/verify", p.VerifyForwarded(true, "")).MatcherFunc(p.fromTrustedProxy)
 
 	return r
 }
 
+// registerFwdAuthPrefixHandlers registers a forward-auth endpoint, for
+// trusted proxies, on every host. The path of the original request follows
+// forwardAuthPrefix, as when sent by envoy's external authorization filter,
+// which keeps the original request's host.
+func (p *Proxy) registerFwdAuthPrefixHandlers(r *mux.Router) *mux.Router {
+	h := r.PathPrefix(forwardAuthPrefix + "/").MatcherFunc(p.fromTrustedProxy).Subrouter()
+	h.Use(sessions.RetrieveSession(p.sessionStore))
+	h.PathPrefix("/").Handler(p.VerifyForwarded(false, forwardAuthPrefix))
+	return r
+}
+
+// fromTrustedProxy returns true if the request was made by one of the
+// forward-auth trusted proxies.
+func (p *Proxy) fromTrustedProxy(r *http.Request, _ *mux.RouteMatch) bool {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, cidr := range p.trustedProxies {
+		if cidr.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // postSessionSetNOP after successfully setting the
 func (p *Proxy) postSessionSetNOP(w http.ResponseWriter, r *http.Request) error {
 	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
@@ -77,37 +117,210 @@ func (p *Proxy) Verify(verifyOnly bool) http.Handler {
 		if err != nil {
 			return httputil.NewError(http.StatusBadRequest, err)
 		}
+		return p.verify(w, r, uri, verifyOnly, false)
+	})
+}
 
-		s, err := sessions.FromContext(r.Context())
-		if errors.Is(err, sessions.ErrNoSessionFound) || errors.Is(err, sessions.ErrExpired) {
-			if verifyOnly {
-				return httputil.NewError(http.StatusUnauthorized, err)
+// VerifyForwarded is like Verify, but for requests from trusted proxies that
+// describe the original request with headers. The request to verify is read
+// from `X-Original-URL`, if set, and otherwise from `X-Forwarded-Proto`,
+// `X-Forwarded-Host` and `X-Forwarded-Uri`. If prefix is set, the original
+// request's path, and host, may instead be those of the request itself, with
+// prefix removed. Only GET and HEAD requests are redirected to sign in.
+// As the proxy is trusted, the route's upstream headers are also returned.
+func (p *Proxy) VerifyForwarded(verifyOnly bool, prefix string) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		method, uri, err := forwardedRequest(r, prefix)
+		if err != nil {
+			return httputil.NewError(http.StatusBadRequest, err)
+		}
+		// the user has signed in, and been sent back to the original url
+		q := uri.Query()
+		if encryptedSession := q.Get(urlutil.QuerySessionEncrypted); encryptedSession != "" {
+			if _, err := p.saveCallbackSession(w, r, encryptedSession); err != nil {
+				return httputil.NewError(http.StatusBadRequest, err)
 			}
-			authN := *p.authenticateSigninURL
-			q := authN.Query()
-			q.Set(urlutil.QueryCallbackURI, uri.String())
-			q.Set(urlutil.QueryRedirectURI, uri.String())              // final destination
-			q.Set(urlutil.QueryForwardAuth, urlutil.StripPort(r.Host)) // add fwd auth to trusted audience
-			authN.RawQuery = q.Encode()
-			httputil.Redirect(w, r, urlutil.NewSignedURL(p.SharedKey, &authN).String(), http.StatusFound)
+			w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+			httputil.Redirect(w, r, q.Get(urlutil.QueryRedirectURI), http.StatusFound)
 			return nil
-		} else if err != nil {
-			return httputil.NewError(http.StatusUnauthorized, err)
 		}
-		// depending on the configuration of the fronting proxy, the request Host
-		// and/or `X-Forwarded-Host` may be untrustd or change so we reverify
-		// the session's validity against the supplied uri
-		if err := s.Verify(uri.Hostname()); err != nil {
+		return p.verify(w, r, uri, verifyOnly || (method != http.MethodGet && method != http.MethodHead), true)
+	})
+}
+
+// verify checks the session may access uri. Only trusted proxies get the
+// route's upstream headers, such as set request headers, in the response:
+// anyone can ask to verify a url, and would otherwise learn the credentials
+// a route hands its upstream.
+func (p *Proxy) verify(w http.ResponseWriter, r *http.Request, uri *url.URL, verifyOnly, routeHeaders bool) error {
+	s, err := sessions.FromContext(r.Context())
+	if errors.Is(err, sessions.ErrNoSessionFound) || errors.Is(err, sessions.ErrExpired) {
+		if verifyOnly {
 			return httputil.NewError(http.StatusUnauthorized, err)
 		}
-		p.addPomeriumHeaders(w, r)
-		if err := p.authorize(uri.Host, r); err != nil {
-			return err
+		authN := *p.authenticateSigninURL
+		q := authN.Query()
+		q.Set(urlutil.QueryCallbackURI, uri.String())
+		q.Set(urlutil.QueryRedirectURI, uri.String())              // final destination
+		q.Set(urlutil.QueryForwardAuth, urlutil.StripPort(r.Host)) // add fwd auth to trusted audience
+		authN.RawQuery = q.Encode()
+		httputil.Redirect(w, r, urlutil.NewSignedURL(p.SharedKey, &authN).String(), http.StatusFound)
+		return nil
+	} else if err != nil {
+		return httputil.NewError(http.StatusUnauthorized, err)
+	}
+	// depending on the configuration of the fronting proxy, the request Host
+	// and/or `X-Forwarded-Host` may be untrustd or change so we reverify
+	// the session's validity against the supplied uri
+	if err := s.Verify(uri.Hostname()); err != nil {
+		return httputil.NewError(http.StatusUnauthorized, err)
+	}
+	p.addPomeriumHeaders(w, r)
+	if err := p.authorize(p.routeID(uri.Host, uri.Path), r); err != nil {
+		return err
+	}
+	if routeHeaders && !p.setRouteHeaders(w, r, uri) {
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "Access to %s is allowed.", uri.Host)
+	return nil
+}
+
+// forwardedRequest returns the method, and url, of the original request a
+// fronting proxy asks to verify, as described by the request's headers.
+func forwardedRequest(r *http.Request, prefix string) (string, *url.URL, error) {
+	method := r.Header.Get(httputil.HeaderForwardedMethod)
+	if method == "" {
+		method = r.Header.Get(httputil.HeaderOriginalMethod)
+	}
+	if method == "" {
+		method = r.Method
+	}
+
+	// nginx, and others, send the whole url; some only send its path
+	requestURI := r.Header.Get(httputil.HeaderOriginalURL)
+	if requestURI != "" && !strings.HasPrefix(requestURI, "/") {
+		u, err := urlutil.ParseAndValidateURL(requestURI)
+		if err != nil {
+			return "", nil, err
 		}
+		return method, u, nil
+	}
+	if requestURI == "" {
+		requestURI = r.Header.Get(httputil.HeaderForwardedURI)
+	}
+	host := firstHeaderValue(r.Header.Get(httputil.HeaderForwardedHost))
+	if prefix != "" {
+		if requestURI == "" {
+			requestURI = strings.TrimPrefix(r.URL.RequestURI(), prefix)
+		}
+		if host == "" {
+			host = r.Host
+		}
+	}
+	if requestURI == "" || host == "" {
+		return "", nil, errors.New("proxy: forwarded request has no uri or host")
+	}
+	if !strings.HasPrefix(requestURI, "/") {
+		requestURI = "/" + requestURI
+	}
+	scheme := firstHeaderValue(r.Header.Get(httputil.HeaderForwardedProto))
+	if scheme == "" {
+		scheme = "https"
+	}
+	if scheme != "http" && scheme != "https" {
+		return "", nil, fmt.Errorf("proxy: forwarded request has bad scheme %q", scheme)
+	}
+	u, err := urlutil.ParseAndValidateURL(scheme + "://" + host + requestURI)
+	if err != nil {
+		return "", nil, err
+	}
+	return method, u, nil
+}
 
-		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, "Access to %s is allowed.", uri.Host)
+// firstHeaderValue returns the first of a header's comma separated values,
+// as added to by each proxy a request passes through.
+func firstHeaderValue(v string) string {
+	if i := strings.IndexByte(v, ','); i != -1 {
+		v = v[:i]
+	}
+	return strings.TrimSpace(v)
+}
+
+// registerVerifyRoute registers the headers a policy's route sends upstream,
+// so that verified requests to the route get the same headers. Public, TCP
+// and static routes send none.
+//
+// Kubernetes routes send none either: only the headers the route's middleware
+// adds, or changes, are returned, so the fronting proxy would pass the
+// client's own impersonation headers on alongside the service account's token.
+func (p *Proxy) registerVerifyRoute(r *mux.Router, policy *config.Policy) error {
+	if policy.AllowPublicUnauthenticatedAccess || policy.IsTCP() || policy.IsStatic() {
 		return nil
-	})
+	}
+	if policy.KubernetesServiceAccountToken != "" {
+		return nil
+	}
+	headers, err := p.routeHeaders(policy)
+	if err != nil {
+		return err
+	}
+	rh := r.Host(policy.Source.Host).MatcherFunc(matchPath(policy)).Subrouter()
+	if policy.ForwardIDPToken != "" {
+		rh.Use(p.LoadIDPTokens)
+	}
+	rh.Use(headers...)
+	var handler http.Handler = http.HandlerFunc(copyRouteHeaders)
+	if len(policy.DestinationGroups) != 0 {
+		handler, err = p.destinationGroupsHandler(policy, func(gp *config.Policy) (http.Handler, error) {
+			return p.signForDestination(gp, http.HandlerFunc(copyRouteHeaders))
+		})
+		if err != nil {
+			return err
+		}
+	}
+	rh.PathPrefix("/").Handler(handler)
+	return nil
+}
+
+type routeHeadersKey struct{}
+
+// routeHeadersState is the state of a verified request's route headers.
+type routeHeadersState struct {
+	original http.Header
+	set      bool
+}
+
+// setRouteHeaders sets the headers the route of uri would send upstream on
+// the response, for the fronting proxy to pass on. It returns false if the
+// route's middleware replied to the request itself, as when the user must
+// sign in again to hand over their identity provider tokens.
+func (p *Proxy) setRouteHeaders(w http.ResponseWriter, r *http.Request, uri *url.URL) bool {
+	req := r.Clone(r.Context())
+	req.URL, req.Host, req.RequestURI = uri, uri.Host, ""
+	var match mux.RouteMatch
+	if p.verifyRoutes == nil || !p.verifyRoutes.Match(req, &match) {
+		return true
+	}
+	state := &routeHeadersState{original: req.Header.Clone()}
+	match.Handler.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), routeHeadersKey{}, state)))
+	return state.set
+}
+
+// copyRouteHeaders sets the request headers added, or changed, by a route's
+// middleware on the response.
+func copyRouteHeaders(w http.ResponseWriter, r *http.Request) {
+	state, ok := r.Context().Value(routeHeadersKey{}).(*routeHeadersState)
+	if !ok {
+		return
+	}
+	for key, values := range r.Header {
+		if !reflect.DeepEqual(values, state.original[key]) {
+			w.Header()[key] = values
+		}
+	}
+	state.set = true
 }
@@ -1,6 +1,10 @@
 package proxy
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -10,6 +14,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/cryptutil"
 	"github.com/pomerium/pomerium/internal/encoding"
 	"github.com/pomerium/pomerium/internal/encoding/mock"
 	"github.com/pomerium/pomerium/internal/httputil"
@@ -117,3 +122,196 @@ func TestProxy_ForwardAuth(t *testing.T) {
 		})
 	}
 }
+
+func Test_forwardedRequest(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		prefix  string
+		path    string
+		headers map[string]string
+
+		wantMethod string
+		wantURL    string
+		wantErr    bool
+	}{
+		{"traefik", "", "/", map[string]string{"X-Forwarded-Method": "POST", "X-Forwarded-Proto": "https", "X-Forwarded-Host": "app.example", "X-Forwarded-Uri": "/path?q=1"}, http.MethodPost, "https://app.example/path?q=1", false},
+		{"caddy", "", "/", map[string]string{"X-Forwarded-Method": "GET", "X-Forwarded-Proto": "http", "X-Forwarded-Host": "app.example:8080", "X-Forwarded-Uri": "/path"}, http.MethodGet, "http://app.example:8080/path", false},
+		{"haproxy", "", "/verify", map[string]string{"X-Forwarded-Proto": "https, http", "X-Forwarded-Host": "app.example, fwdauth.example", "X-Forwarded-Uri": "/"}, http.MethodGet, "https://app.example/", false},
+		{"nginx", "", "/verify", map[string]string{"X-Original-Method": "DELETE", "X-Original-Url": "https://app.example/path?q=1"}, http.MethodDelete, "https://app.example/path?q=1", false},
+		{"original url path", "", "/", map[string]string{"X-Forwarded-Host": "app.example", "X-Original-Url": "/path"}, http.MethodGet, "https://app.example/path", false},
+		{"envoy", "/.pomerium/verify", "/.pomerium/verify/path?q=1", map[string]string{"X-Forwarded-Proto": "http"}, http.MethodGet, "http://app.example/path?q=1", false},
+		{"envoy root", "/.pomerium/verify", "/.pomerium/verify/", nil, http.MethodGet, "https://app.example/", false},
+		{"no forwarded uri", "", "/", map[string]string{"X-Forwarded-Host": "app.example"}, "", "", true},
+		{"no forwarded host", "", "/", map[string]string{"X-Forwarded-Uri": "/path"}, "", "", true},
+		{"bad scheme", "", "/", map[string]string{"X-Forwarded-Proto": "ftp", "X-Forwarded-Host": "app.example", "X-Forwarded-Uri": "/path"}, "", "", true},
+		{"bad original url", "", "/", map[string]string{"X-Original-Url": "app.example/path"}, "", "", true},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := httptest.NewRequest(http.MethodGet, "http://app.example"+tt.path, nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			method, u, err := forwardedRequest(r, tt.prefix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("forwardedRequest() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if method != tt.wantMethod {
+				t.Errorf("forwardedRequest() method = %q, want %q", method, tt.wantMethod)
+			}
+			if u.String() != tt.wantURL {
+				t.Errorf("forwardedRequest() url = %q, want %q", u.String(), tt.wantURL)
+			}
+		})
+	}
+}
+
+func TestProxy_VerifyForwarded(t *testing.T) {
+	t.Parallel()
+	opts := testOptions(t)
+	opts.ForwardAuthURLString = "https://fwdauth.example"
+	opts.ForwardAuthTrustedProxies = []string{"10.0.0.0/8"}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	session := &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}
+	noSession := &sessions.MockSessionStore{LoadError: sessions.ErrNoSessionFound}
+	traefik := map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "app.example", "X-Forwarded-Uri": "/path"}
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		url          string
+		method       string
+		headers      map[string]string
+		sessionStore sessions.SessionStore
+		authorized   bool
+
+		wantStatus int
+	}{
+		{"authorized", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, traefik, session, true, http.StatusOK},
+		{"authorized verify only", "10.0.0.1:1234", "https://fwdauth.example/verify", http.MethodGet, traefik, session, true, http.StatusOK},
+		{"not authorized", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, traefik, session, false, http.StatusUnauthorized},
+		{"untrusted proxy", "192.0.2.1:1234", "https://fwdauth.example/", http.MethodGet, traefik, session, true, http.StatusNotFound},
+		{"no session, redirect", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, traefik, noSession, true, http.StatusFound},
+		{"no session, verify only", "10.0.0.1:1234", "https://fwdauth.example/verify", http.MethodGet, traefik, noSession, true, http.StatusUnauthorized},
+		{"no session, post", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, map[string]string{"X-Forwarded-Method": "POST", "X-Forwarded-Host": "app.example", "X-Forwarded-Uri": "/path"}, noSession, true, http.StatusUnauthorized},
+		{"bad forwarded request", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, map[string]string{"X-Forwarded-Host": "app.example"}, session, true, http.StatusBadRequest},
+		{"callback", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, map[string]string{"X-Original-Url": "https://app.example/?" + urlutil.QuerySessionEncrypted + "=" + goodEncryptionString + "&" + urlutil.QueryRedirectURI + "=https://app.example/"}, noSession, true, http.StatusFound},
+		{"bad callback", "10.0.0.1:1234", "https://fwdauth.example/", http.MethodGet, map[string]string{"X-Original-Url": "https://app.example/?" + urlutil.QuerySessionEncrypted + "=garbage"}, noSession, true, http.StatusBadRequest},
+		{"envoy", "10.0.0.1:1234", "https://app.example/.pomerium/verify/path", http.MethodGet, nil, session, true, http.StatusOK},
+		{"envoy not authorized", "10.0.0.1:1234", "https://app.example/.pomerium/verify/path", http.MethodGet, nil, session, false, http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.sessionStore = tt.sessionStore
+			p.AuthorizeClient = clients.MockAuthorize{AuthorizeResponse: tt.authorized}
+			if err := p.UpdateOptions(opts); err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(tt.method, tt.url, nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("Accept", "application/json")
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			p.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status code: got %v want %v: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestProxy_Verify_routeHeaders(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey, err := cryptutil.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := testOptions(t)
+	opts.ForwardAuthURLString = "https://fwdauth.example"
+	opts.SigningKey = base64.StdEncoding.EncodeToString(pemKey)
+	opts.ForwardAuthTrustedProxies = []string{"10.0.0.0/8"}
+	opts.Policies = []config.Policy{
+		{From: "https://app.example", Prefix: "/admin", To: "https://admin.internal", SetRequestHeaders: map[string]string{"X-Custom": "admin"}},
+		{From: "https://app.example", To: "https://app.internal", SetRequestHeaders: map[string]string{"X-Custom": "app"}},
+		{From: "https://k8s.example", To: "https://kubernetes.internal", KubernetesServiceAccountToken: "service-account-token"},
+		{From: "https://public.example", To: "https://public.internal", AllowPublicUnauthenticatedAccess: true, SetRequestHeaders: map[string]string{"X-Custom": "public"}},
+	}
+	if err := opts.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name           string
+		verifyURI      string
+		trusted        bool
+		requestHeaders map[string]string
+
+		wantHeaders map[string][]string
+		wantJWT     bool
+	}{
+		{"custom headers", "https://app.example/", true, nil, map[string][]string{"X-Custom": {"app"}}, true},
+		{"route prefix", "https://app.example/admin/users", true, nil, map[string][]string{"X-Custom": {"admin"}}, true},
+		{"kubernetes", "https://k8s.example/api", true, nil, map[string][]string{"Authorization": nil, "Impersonate-User": nil, "Impersonate-Group": nil}, false},
+		{"kubernetes client impersonation", "https://k8s.example/api", true, map[string]string{"Impersonate-Group": "system:masters"}, map[string][]string{"Authorization": nil, "Impersonate-User": nil, "Impersonate-Group": nil}, false},
+		{"public route", "https://public.example/", true, nil, map[string][]string{"X-Custom": nil}, false},
+		{"unknown route", "https://unknown.example/", true, nil, map[string][]string{"X-Custom": nil}, false},
+		{"untrusted", "https://app.example/", false, nil, map[string][]string{"X-Custom": nil}, false},
+		{"untrusted route prefix", "https://app.example/admin/users", false, nil, map[string][]string{"X-Custom": nil}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := New(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.sessionStore = &sessions.MockSessionStore{Session: &sessions.State{Subject: "user", Email: "user@test.example", Groups: []string{"a", "b"}, Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}
+			p.AuthorizeClient = clients.MockAuthorize{AuthorizeResponse: true}
+			if err := p.UpdateOptions(opts); err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(http.MethodGet, "https://fwdauth.example/verify?uri="+url.QueryEscape(tt.verifyURI), nil)
+			if tt.trusted {
+				r = httptest.NewRequest(http.MethodGet, "https://fwdauth.example/verify", nil)
+				r.RemoteAddr = "10.0.0.1:1234"
+				r.Header.Set(httputil.HeaderOriginalURL, tt.verifyURI)
+			}
+			for k, v := range tt.requestHeaders {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			p.ServeHTTP(w, r)
+			if w.Code != http.StatusOK {
+				t.Fatalf("status code: got %v want %v: %s", w.Code, http.StatusOK, w.Body.String())
+			}
+			for key, want := range tt.wantHeaders {
+				if diff := cmp.Diff(want, w.Header()[key]); diff != "" {
+					t.Errorf("header %s: %s", key, diff)
+				}
+			}
+			if got := w.Header().Get(HeaderJWT) != ""; got != tt.wantJWT {
+				t.Errorf("jwt header set = %v, want %v", got, tt.wantJWT)
+			}
+			if got := w.Header().Get(HeaderEmail); got != "user@test.example" {
+				t.Errorf("email header = %q, want %q", got, "user@test.example")
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// h2cTransport returns a transport that speaks HTTP/2, with prior knowledge
+// and without TLS, to a `grpc://` or `h2c://` destination. Requests must use
+// the `http` scheme.
+func h2cTransport() *http2.Transport {
+	// the same dial timeouts as http.DefaultTransport; DialTLS is not given
+	// the request's context, so the timeout bounds each dial instead
+	dialer := net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	return &http2.Transport{
+		AllowHTTP: true,
+		DialTLS: func(network, addr string, _ *tls.Config) (net.Conn, error) {
+			return dialer.Dial(network, addr)
+		},
+	}
+}
+
+// isGRPCRequest returns true if the request is a gRPC call.
+//
+// https://github.com/grpc/grpc/blob/master/doc/PROTOCOL-HTTP2.md
+func isGRPCRequest(r *http.Request) bool {
+	return strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc")
+}
+
+// unlessGRPC applies a middleware to all but gRPC calls, whose streamed
+// messages and trailers cannot pass through a buffering middleware such as
+// the timeout handler. gRPC clients set their own deadlines instead.
+func unlessGRPC(mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isGRPCRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package proxy
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+	"golang.org/x/net/http2/h2c"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+func TestReverseProxy_H2C(t *testing.T) {
+	t.Parallel()
+	// a gRPC-like upstream that only speaks cleartext HTTP/2, and replies
+	// with its status in the trailers
+	upstream := httptest.NewServer(h2c.NewHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ProtoMajor != 2 {
+			http.Error(w, "http/2 required", http.StatusHTTPVersionNotSupported)
+			return
+		}
+		w.Header().Set("Content-Type", "application/grpc")
+		w.Header().Set("Trailer", "Grpc-Status")
+		w.WriteHeader(http.StatusOK)
+		io.Copy(w, r.Body)
+		w.Header().Set("Grpc-Status", "0")
+	}), &http2.Server{}))
+	defer upstream.Close()
+
+	tests := []struct {
+		name string
+		to   string
+	}{
+		{"grpc", strings.Replace(upstream.URL, "http://", "grpc://", 1)},
+		{"h2c", strings.Replace(upstream.URL, "http://", "h2c://", 1)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policy := config.Policy{From: "https://grpc.corp.example", To: tt.to}
+			if err := policy.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			if !policy.IsH2C() {
+				t.Fatalf("IsH2C() = false, want true")
+			}
+			p := &Proxy{}
+			srv := httptest.NewServer(h2c.NewHandler(p.reverseProxy(&policy), &http2.Server{}))
+			defer srv.Close()
+
+			client := &http.Client{Transport: h2cTransport()}
+			res, err := client.Post(srv.URL, "application/grpc", strings.NewReader("message"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer res.Body.Close()
+			body, err := ioutil.ReadAll(res.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != http.StatusOK {
+				t.Fatalf("status = %d, want %d: %s", res.StatusCode, http.StatusOK, body)
+			}
+			if string(body) != "message" {
+				t.Errorf("body = %q, want %q", body, "message")
+			}
+			if got := res.Trailer.Get("Grpc-Status"); got != "0" {
+				t.Errorf("trailer Grpc-Status = %q, want %q", got, "0")
+			}
+		})
+	}
+}
+
+func Test_unlessGRPC(t *testing.T) {
+	t.Parallel()
+	timeout := unlessGRPC(func(next http.Handler) http.Handler {
+		return http.TimeoutHandler(next, time.Millisecond, "timed out")
+	})
+	h := timeout(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(10 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+	}))
+	tests := []struct {
+		name        string
+		contentType string
+		wantStatus  int
+	}{
+		{"grpc", "application/grpc", http.StatusOK},
+		{"grpc with codec", "application/grpc+proto", http.StatusOK},
+		{"http", "application/json", http.StatusServiceUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Content-Type", tt.contentType)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -2,15 +2,21 @@ package proxy // import "github.com/pomerium/pomerium/proxy"
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/pomerium/csrf"
+	"github.com/rs/cors"
+	"golang.org/x/net/publicsuffix"
 
 	"github.com/pomerium/pomerium/internal/cryptutil"
 	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
 	"github.com/pomerium/pomerium/internal/middleware"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/internal/urlutil"
@@ -34,6 +40,7 @@ func (p *Proxy) registerDashboardHandlers(r *mux.Router) *mux.Router {
 	// dashboard endpoints can be used by user's to view, or modify their session
 	h.Path("/").Handler(httputil.HandlerFunc(p.UserDashboard)).Methods(http.MethodGet)
 	h.Path("/impersonate").Handler(httputil.HandlerFunc(p.Impersonate)).Methods(http.MethodPost)
+	h.Path("/sessions/revoke").Handler(httputil.HandlerFunc(p.RevokeSession)).Methods(http.MethodPost)
 	h.Path("/sign_out").HandlerFunc(p.SignOut).Methods(http.MethodGet, http.MethodPost)
 
 	// Authenticate service callback handlers and middleware
@@ -54,6 +61,17 @@ func (p *Proxy) registerDashboardHandlers(r *mux.Router) *mux.Router {
 	a.Path("/v1/login").Handler(httputil.HandlerFunc(p.ProgrammaticLogin)).
 		Queries(urlutil.QueryRedirectURI, "").
 		Methods(http.MethodGet)
+	// whoami api handler returns the user's session, and allows credentialed
+	// cross origin requests from the same site
+	whoami := cors.New(cors.Options{
+		AllowOriginRequestFunc: sameSiteOrigin,
+		AllowCredentials:       true,
+		AllowedMethods:         []string{http.MethodGet},
+		AllowedHeaders:         []string{"Authorization"},
+	})
+	a.Path("/v1/whoami").
+		Handler(whoami.Handler(sessions.RetrieveSession(p.sessionLoaders...)(httputil.HandlerFunc(p.WhoAmI)))).
+		Methods(http.MethodGet, http.MethodOptions)
 
 	return r
 }
@@ -65,6 +83,15 @@ func (p *Proxy) RobotsTxt(w http.ResponseWriter, _ *http.Request) {
 	fmt.Fprintf(w, "User-agent: *\nDisallow: /")
 }
 
+// JWKS returns the public key set of the key signing identity headers, so
+// that upstreams can verify them.
+func (p *Proxy) JWKS(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.WriteHeader(http.StatusOK)
+	w.Write(p.jwks)
+}
+
 // SignOut redirects the request to the sign out url. It's the responsibility
 // of the authenticate service to revoke the remote session and clear
 // the local session state.
@@ -80,6 +107,7 @@ func (p *Proxy) SignOut(w http.ResponseWriter, r *http.Request) {
 	signoutURL.RawQuery = q.Encode()
 
 	p.sessionStore.ClearSession(w, r)
+	p.tokenStore.ClearSession(w, r)
 	httputil.Redirect(w, r, urlutil.NewSignedURL(p.SharedKey, &signoutURL).String(), http.StatusFound)
 }
 
@@ -97,6 +125,16 @@ func (p *Proxy) UserDashboard(w http.ResponseWriter, r *http.Request) error {
 		return err
 	}
 
+	// administrators can look up the sessions of any user
+	sessionsUser := session.Subject
+	if user := r.FormValue("user"); isAdmin && user != "" {
+		sessionsUser = user
+	}
+	userSessions, err := p.inventory.list(r.Context(), sessionsUser)
+	if err != nil {
+		log.FromRequest(r).Warn().Err(err).Msg("proxy: dashboard, list sessions")
+	}
+
 	p.templates.ExecuteTemplate(w, "dashboard.html", map[string]interface{}{
 		"Session":           session,
 		"IsAdmin":           isAdmin,
@@ -104,10 +142,41 @@ func (p *Proxy) UserDashboard(w http.ResponseWriter, r *http.Request) error {
 		"ImpersonateAction": urlutil.QueryImpersonateAction,
 		"ImpersonateEmail":  urlutil.QueryImpersonateEmail,
 		"ImpersonateGroups": urlutil.QueryImpersonateGroups,
+		"Sessions":          userSessions,
+		"SessionsUser":      sessionsUser,
 	})
 	return nil
 }
 
+// RevokeSession takes the result of a form and revokes one of the user's
+// sessions or, if the user is an administrator, any user's session. Requests
+// are redirected back to the user dashboard.
+func (p *Proxy) RevokeSession(w http.ResponseWriter, r *http.Request) error {
+	session, err := sessions.FromContext(r.Context())
+	if err != nil {
+		return err
+	}
+	isAdmin, err := p.AuthorizeClient.IsAdmin(r.Context(), session)
+	if err != nil {
+		return err
+	}
+	user := session.Subject
+	if isAdmin {
+		user = r.FormValue("user")
+	}
+	if err := p.inventory.revoke(r.Context(), r.FormValue("id"), user); err != nil {
+		return httputil.NewError(http.StatusBadRequest, err)
+	}
+	redirectURL := urlutil.GetAbsoluteURL(r)
+	redirectURL.Path = dashboardURL + "/" // redirect back to the dashboard
+	redirectURL.RawQuery = ""
+	if user != "" && user != session.Subject {
+		redirectURL.RawQuery = url.Values{"user": {user}}.Encode()
+	}
+	httputil.Redirect(w, r, redirectURL.String(), http.StatusFound)
+	return nil
+}
+
 // Impersonate takes the result of a form and adds user impersonation details
 // to the user's current user sessions state if the user is currently an
 // administrative user. Requests are redirected back to the user dashboard.
@@ -146,6 +215,12 @@ func (p *Proxy) Callback(w http.ResponseWriter, r *http.Request) error {
 	if _, err := p.saveCallbackSession(w, r, encryptedSession); err != nil {
 		return httputil.NewError(http.StatusBadRequest, err)
 	}
+	// identity provider tokens are only handed over if a route forwards them
+	if encryptedTokens := r.FormValue(urlutil.QuerySessionTokens); encryptedTokens != "" {
+		if err := p.saveCallbackTokens(w, r, encryptedTokens); err != nil {
+			return httputil.NewError(http.StatusBadRequest, err)
+		}
+	}
 	httputil.Redirect(w, r, redirectURLString, http.StatusFound)
 	return nil
 }
@@ -170,6 +245,20 @@ func (p *Proxy) saveCallbackSession(w http.ResponseWriter, r *http.Request, enct
 	return rawJWT, nil
 }
 
+// saveCallbackTokens checks the user's identity provider tokens, encrypted
+// with the shared service key, can be decrypted and stores them in their own
+// cookie.
+func (p *Proxy) saveCallbackTokens(w http.ResponseWriter, r *http.Request, enctokens string) error {
+	var tokens sessions.State
+	if err := p.idpTokens.encoder.Unmarshal([]byte(enctokens), &tokens); err != nil {
+		return fmt.Errorf("proxy: malformed callback tokens: %w", err)
+	}
+	if err := p.tokenStore.SaveSession(w, r, &tokens); err != nil {
+		return fmt.Errorf("proxy: callback tokens save failure: %w", err)
+	}
+	return nil
+}
+
 // ProgrammaticLogin returns a signed url that can be used to login
 // using the authenticate service.
 func (p *Proxy) ProgrammaticLogin(w http.ResponseWriter, r *http.Request) error {
@@ -193,6 +282,88 @@ func (p *Proxy) ProgrammaticLogin(w http.ResponseWriter, r *http.Request) error
 	return nil
 }
 
+// whoamiResponse is the user's session, as returned by the whoami api.
+type whoamiResponse struct {
+	User   string   `json:"user"`
+	Email  string   `json:"email"`
+	Groups []string `json:"groups"`
+	Name   string   `json:"name,omitempty"`
+
+	Impersonating     bool     `json:"impersonating"`
+	ImpersonateEmail  string   `json:"impersonate_email,omitempty"`
+	ImpersonateGroups []string `json:"impersonate_groups,omitempty"`
+
+	Programmatic   bool       `json:"programmatic"`
+	ServiceAccount bool       `json:"service_account,omitempty"`
+	IssuedAt       *time.Time `json:"issued_at,omitempty"`
+	Expiry         *time.Time `json:"expiry,omitempty"`
+}
+
+// WhoAmI returns the user's identity, groups, impersonation state and
+// session expiry as JSON, so that frontends can show the current user.
+func (p *Proxy) WhoAmI(w http.ResponseWriter, r *http.Request) error {
+	s, err := sessions.FromContext(r.Context())
+	if err != nil {
+		return httputil.NewError(http.StatusUnauthorized, err)
+	}
+	if s == nil {
+		return httputil.NewError(http.StatusUnauthorized, sessions.ErrNoSessionFound)
+	}
+	response := whoamiResponse{
+		User:              s.Subject,
+		Email:             s.Email,
+		Groups:            s.Groups,
+		Name:              s.Name,
+		Impersonating:     s.ImpersonateEmail != "" || len(s.ImpersonateGroups) != 0,
+		ImpersonateEmail:  s.ImpersonateEmail,
+		ImpersonateGroups: s.ImpersonateGroups,
+		Programmatic:      s.Programmatic,
+		ServiceAccount:    s.ServiceAccount,
+	}
+	if response.Groups == nil {
+		response.Groups = []string{}
+	}
+	if s.IssuedAt != nil {
+		t := s.IssuedAt.Time().UTC()
+		response.IssuedAt = &t
+	}
+	if s.Expiry != nil {
+		t := s.Expiry.Time().UTC()
+		response.Expiry = &t
+	}
+	jsonResponse, err := json.Marshal(&response)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(jsonResponse)
+	return nil
+}
+
+// sameSiteOrigin returns true if the origin is an https url on the same
+// site, the same registrable domain, as the request.
+func sameSiteOrigin(r *http.Request, origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	return site(u.Hostname()) == site(urlutil.StripPort(r.Host))
+}
+
+// site returns the registrable domain of host, e.g. `example.com` for
+// `app.corp.example.com`. Hosts without one, such as ip addresses, are
+// their own site.
+func site(host string) string {
+	if net.ParseIP(host) != nil {
+		return host
+	}
+	if s, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return s
+	}
+	return host
+}
+
 // ProgrammaticCallback handles a successful call to the authenticate service.
 // In addition to returning the individual route session (JWT) it also returns
 // the refresh token.
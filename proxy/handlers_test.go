@@ -2,6 +2,11 @@ package proxy
 
 import (
 	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,6 +20,7 @@ import (
 
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/encoding/jws"
 	"github.com/pomerium/pomerium/internal/encoding/mock"
 	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/sessions"
@@ -22,6 +28,7 @@ import (
 	"github.com/pomerium/pomerium/proxy/clients"
 
 	"github.com/google/go-cmp/cmp"
+	jose "gopkg.in/square/go-jose.v2"
 	"gopkg.in/square/go-jose.v2/jwt"
 )
 
@@ -248,6 +255,7 @@ func TestProxy_Callback(t *testing.T) {
 		{"good", opts, http.MethodGet, "http", "example.com", "/", nil, map[string]string{urlutil.QueryCallbackURI: "ok", urlutil.QuerySessionEncrypted: goodEncryptionString}, &mock.Encoder{MarshalResponse: []byte("x")}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusFound, ""},
 		{"good programmatic", opts, http.MethodGet, "http", "example.com", "/", nil, map[string]string{urlutil.QueryIsProgrammatic: "true", urlutil.QueryCallbackURI: "ok", urlutil.QuerySessionEncrypted: goodEncryptionString}, &mock.Encoder{MarshalResponse: []byte("x")}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusFound, ""},
 		{"bad decrypt", opts, http.MethodGet, "http", "example.com", "/", nil, map[string]string{urlutil.QuerySessionEncrypted: "KBEjQ9rnCxaAX-GOqexGw9ivEQURqts3zZ2mNGy0wnVa3SbtM399KlBq2nZ-9wM21FfsZX52er4jlmC7kPEKM3P7uZ41zR0zeys1-_74a5tQp-vsf1WXZfRsgVOuBcWPkMiWEoc379JFHxGDudp5VhU8B-dcQt4f3_PtLTHARkuH54io1Va2gNMq4Hiy8sQ1MPGCQeltH_JMzzdDpXdmdusWrXUvCGkba24muvAV06D8XRVJj6Iu9eK94qFnqcHc7wzziEbb8ADBues9dwbtb6jl8vMWz5rN6XvXqA5YpZv_MQZlsrO4oXFFQDevdgB84cX1tVbVu6qZvK_yQBZqzpOjWA9uIaoSENMytoXuWAlFO_sXjswfX8JTNdGwzB7qQRNPqxVG_sM_tzY3QhPm8zqwEzsXG5DokxZfVt2I5WJRUEovFDb4BnK9KFnnkEzLEdMudixVnXeGmTtycgJvoTeTCQRPfDYkcgJ7oKf4tGea-W7z5UAVa2RduJM9ZoM6YtJX7jgDm__PvvqcE0knJUF87XHBzdcOjoDF-CUze9xDJgNBlvPbJqVshKrwoqSYpePSDH9GUCNKxGequW3Ma8GvlFfhwd0rK6IZG-XWkyk0XSWQIGkDSjAvhB1wsOusCCguDjbpVZpaW5MMyTkmx68pl6qlIKT5UCcrVPl4ix5ZEj91mUDF0O1t04haD7VZuLVFXVGmqtFrBKI76sdYN-zkokaa1_chPRTyqMQFlqu_8LD6-RiK3UccGM-dEmnX72i91NP9F9OK0WJr9Cheup1C_P0mjqAO4Cb8oIHm0Oxz_mRqv5QbTGJtb3xwPLPuVjVCiE4gGBcuU2ixpSVf5HUF7y1KicVMCKiX9ATCBtg8sTdQZQnPEtHcHHAvdsnDVwev1LGfqA-Gdvg="}, &mock.Encoder{MarshalResponse: []byte("x")}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusBadRequest, ""},
+		{"bad tokens", opts, http.MethodGet, "http", "example.com", "/", nil, map[string]string{urlutil.QueryCallbackURI: "ok", urlutil.QuerySessionEncrypted: goodEncryptionString, urlutil.QuerySessionTokens: "garbage"}, &mock.Encoder{MarshalResponse: []byte("x")}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusBadRequest, ""},
 		{"bad save session", opts, http.MethodGet, "http", "example.com", "/", nil, map[string]string{urlutil.QuerySessionEncrypted: goodEncryptionString}, &mock.Encoder{MarshalResponse: []byte("x")}, &sessions.MockSessionStore{SaveError: errors.New("hi")}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusBadRequest, ""},
 		{"bad base64", opts, http.MethodGet, "http", "example.com", "/", nil, map[string]string{urlutil.QuerySessionEncrypted: "^"}, &mock.Encoder{MarshalResponse: []byte("x")}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusBadRequest, ""},
 		{"malformed redirect", opts, http.MethodGet, "http", "example.com", "/", nil, nil, &mock.Encoder{}, &sessions.MockSessionStore{Session: &sessions.State{Email: "user@test.example", Expiry: jwt.NewNumericDate(time.Now().Add(10 * time.Minute))}}, clients.MockAuthorize{AuthorizeResponse: true}, http.StatusBadRequest, ""},
@@ -445,3 +453,130 @@ func TestProxy_ProgrammaticCallback(t *testing.T) {
 		})
 	}
 }
+
+func TestProxy_WhoAmI(t *testing.T) {
+	t.Parallel()
+	opts := testOptions(t)
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := &sessions.State{
+		Subject:  "user",
+		Email:    "user@corp.example",
+		Groups:   []string{"admins"},
+		Audience: jwt.Audience{"httpbin.corp.example"},
+		Expiry:   jwt.NewNumericDate(expiry),
+	}
+	impersonating := *user
+	impersonating.ImpersonateEmail = "other@corp.example"
+	tests := []struct {
+		name    string
+		method  string
+		origin  string
+		session *sessions.State
+
+		wantStatus int
+		wantBody   string
+		wantOrigin string
+	}{
+		{"good", http.MethodGet, "", user, http.StatusOK, `{"user":"user","email":"user@corp.example","groups":["admins"],"impersonating":false,"programmatic":false,"expiry":"2030-01-01T00:00:00Z"}`, ""},
+		{"impersonating", http.MethodGet, "", &impersonating, http.StatusOK, `{"user":"user","email":"user@corp.example","groups":["admins"],"impersonating":true,"impersonate_email":"other@corp.example","programmatic":false,"expiry":"2030-01-01T00:00:00Z"}`, ""},
+		{"no session", http.MethodGet, "", nil, http.StatusUnauthorized, "", ""},
+		{"same site origin", http.MethodGet, "https://app.corp.example", user, http.StatusOK, "", "https://app.corp.example"},
+		{"cross site origin", http.MethodGet, "https://evil.example", user, http.StatusOK, "", ""},
+		{"insecure origin", http.MethodGet, "http://app.corp.example", user, http.StatusOK, "", ""},
+		{"same site preflight", http.MethodOptions, "https://app.corp.example", nil, http.StatusOK, "", "https://app.corp.example"},
+		{"cross site preflight", http.MethodOptions, "https://evil.example", nil, http.StatusOK, "", ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p, err := New(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			store := &sessions.MockSessionStore{Session: tt.session}
+			if tt.session == nil {
+				store.LoadError = sessions.ErrNoSessionFound
+			}
+			p.sessionLoaders = []sessions.SessionLoader{store}
+
+			r := httptest.NewRequest(tt.method, "https://httpbin.corp.example/.pomerium/api/v1/whoami", nil)
+			r.Header.Set("Accept", "application/json")
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.method == http.MethodOptions {
+				r.Header.Set("Access-Control-Request-Method", http.MethodGet)
+			}
+			w := httptest.NewRecorder()
+			p.registerDashboardHandlers(httputil.NewRouter()).ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status code: got %v want %v\n%s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantBody != "" {
+				if diff := cmp.Diff(tt.wantBody, w.Body.String()); diff != "" {
+					t.Errorf("wrong body\n%s", diff)
+				}
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("Access-Control-Allow-Origin: got %q want %q", got, tt.wantOrigin)
+			}
+		})
+	}
+}
+
+func TestProxy_JWKS(t *testing.T) {
+	t.Parallel()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pemKey, err := cryptutil.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := testOptions(t)
+	opts.SigningKey = base64.StdEncoding.EncodeToString(pemKey)
+	p, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the key set is published on every route
+	r := httptest.NewRequest(http.MethodGet, "https://corp.example.example/.well-known/pomerium/jwks.json", nil)
+	w := httptest.NewRecorder()
+	p.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %v, want %v\n%v", w.Code, http.StatusOK, w.Body.String())
+	}
+	var jwks jose.JSONWebKeySet
+	if err := json.Unmarshal(w.Body.Bytes(), &jwks); err != nil {
+		t.Fatal(err)
+	}
+
+	// signed identity headers can be verified with the published key
+	signer, err := jws.NewES256Signer(opts.SigningKey, "corp.example.example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	r = httptest.NewRequest(http.MethodGet, "https://corp.example.example/", nil)
+	r = r.WithContext(sessions.NewContext(r.Context(), &sessions.State{Subject: "user"}, nil))
+	w = httptest.NewRecorder()
+	p.SignRequest(signer)(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})).ServeHTTP(w, r)
+	tok, err := jwt.ParseSigned(w.Header().Get(HeaderJWT))
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := jwks.Key(tok.Headers[0].KeyID)
+	if len(keys) != 1 {
+		t.Fatalf("key %q not in published key set", tok.Headers[0].KeyID)
+	}
+	var claims jwt.Claims
+	if err := tok.Claims(keys[0].Key, &claims); err != nil {
+		t.Fatal(err)
+	}
+	if claims.Subject != "user" {
+		t.Errorf("subject = %q, want %q", claims.Subject, "user")
+	}
+}
@@ -0,0 +1,57 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"net/http"
+)
+
+// removeRequestHeaders wraps a reverse proxy director, removing a set of
+// headers from the downstream request after it has been directed.
+func removeRequestHeaders(director func(*http.Request), headers []string) func(*http.Request) {
+	return func(req *http.Request) {
+		director(req)
+		for _, key := range headers {
+			req.Header.Del(key)
+		}
+	}
+}
+
+// rewriteHost wraps a reverse proxy director, setting the downstream request's
+// host header to the given value. If host is empty, the host header of the
+// incoming request is preserved.
+func rewriteHost(director func(*http.Request), host string) func(*http.Request) {
+	return func(req *http.Request) {
+		newHost := host
+		if newHost == "" {
+			newHost = req.Host
+		}
+		director(req)
+		req.Host = newHost
+	}
+}
+
+// modifyResponseHeaders returns a reverse proxy response modifier that sets,
+// then removes, a set of headers on the upstream's response.
+func modifyResponseHeaders(set map[string]string, remove []string) func(*http.Response) error {
+	return func(res *http.Response) error {
+		for key, val := range set {
+			res.Header.Set(key, val)
+		}
+		for _, key := range remove {
+			res.Header.Del(key)
+		}
+		return nil
+	}
+}
+
+// modifyResponse chains reverse proxy response modifiers. Modifiers are
+// called in order until one returns an error.
+func modifyResponse(modifiers ...func(*http.Response) error) func(*http.Response) error {
+	return func(res *http.Response) error {
+		for _, modify := range modifiers {
+			if err := modify(res); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
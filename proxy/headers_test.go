@@ -0,0 +1,116 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pomerium/pomerium/internal/httputil"
+)
+
+func Test_removeRequestHeaders(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		headers     http.Header
+		remove      []string
+		wantHeaders http.Header
+	}{
+		{"good", http.Header{"X-Secret": {"hunter42"}, "X-Keep": {"ok"}}, []string{"x-secret"}, http.Header{"X-Keep": {"ok"}, "X-Director": {"true"}}},
+		{"missing", http.Header{"X-Keep": {"ok"}}, []string{"X-Secret"}, http.Header{"X-Keep": {"ok"}, "X-Director": {"true"}}},
+		{"removes directed headers", http.Header{"X-Keep": {"ok"}}, []string{"X-Director"}, http.Header{"X-Keep": {"ok"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			r.Header = tt.headers
+			director := func(r *http.Request) { r.Header.Set("X-Director", "true") }
+			removeRequestHeaders(director, tt.remove)(r)
+			if diff := cmp.Diff(tt.wantHeaders, r.Header); diff != "" {
+				t.Errorf("removeRequestHeaders() :\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_rewriteHost(t *testing.T) {
+	t.Parallel()
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host))
+	}))
+	defer backend.Close()
+	backendURL, _ := url.Parse(backend.URL)
+
+	tests := []struct {
+		name     string
+		host     string
+		preserve bool
+		wantHost string
+	}{
+		{"default", "", false, backendURL.Host},
+		{"preserve", "", true, "from.example"},
+		{"rewrite", "custom.example", false, "custom.example"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proxy := httputil.NewReverseProxy(backendURL)
+			if tt.preserve || tt.host != "" {
+				proxy.Director = rewriteHost(proxy.Director, tt.host)
+			}
+			r := httptest.NewRequest(http.MethodGet, "https://from.example/", nil)
+			w := httptest.NewRecorder()
+			proxy.ServeHTTP(w, r)
+			if diff := cmp.Diff(tt.wantHost, w.Body.String()); diff != "" {
+				t.Errorf("rewriteHost() :\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_modifyResponseHeaders(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		headers     http.Header
+		set         map[string]string
+		remove      []string
+		wantHeaders http.Header
+	}{
+		{"set", http.Header{"Server": {"nginx"}}, map[string]string{"x-frame-options": "DENY"}, nil, http.Header{"Server": {"nginx"}, "X-Frame-Options": {"DENY"}}},
+		{"overwrite", http.Header{"X-Frame-Options": {"SAMEORIGIN"}}, map[string]string{"X-Frame-Options": "DENY"}, nil, http.Header{"X-Frame-Options": {"DENY"}}},
+		{"remove", http.Header{"Server": {"nginx"}, "X-Powered-By": {"php"}}, nil, []string{"server", "X-Powered-By"}, http.Header{}},
+		{"set and remove", http.Header{"Server": {"nginx"}}, map[string]string{"X-Frame-Options": "DENY"}, []string{"Server"}, http.Header{"X-Frame-Options": {"DENY"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := &http.Response{Header: tt.headers}
+			if err := modifyResponseHeaders(tt.set, tt.remove)(res); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantHeaders, res.Header); diff != "" {
+				t.Errorf("modifyResponseHeaders() :\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_modifyResponse(t *testing.T) {
+	t.Parallel()
+	var calls []string
+	modifier := func(id string, err error) func(*http.Response) error {
+		return func(*http.Response) error {
+			calls = append(calls, id)
+			return err
+		}
+	}
+	err := modifyResponse(modifier("a", nil), modifier("b", errors.New("error")), modifier("c", nil))(&http.Response{})
+	if err == nil {
+		t.Error("modifyResponse() expected error")
+	}
+	if diff := cmp.Diff([]string{"a", "b"}, calls); diff != "" {
+		t.Errorf("modifyResponse() :\n %s", diff)
+	}
+}
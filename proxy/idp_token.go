@@ -0,0 +1,190 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+// tokensURL is the path to authenticate's identity provider token refresh
+// endpoint
+const tokensURL = "/api/v1/tokens"
+
+// refreshedTokensTTL is how long refreshed identity provider tokens are
+// remembered, so that requests made with the tokens they replace, before the
+// user's cookie is updated, do not refresh them again.
+const refreshedTokensTTL = time.Minute
+
+type idpTokensKey struct{}
+
+// LoadIDPTokens is middleware that retrieves the user's identity provider
+// tokens, if any, and adds them to the request context. It must run before
+// the pomerium cookies are stripped from the request.
+func (p *Proxy) LoadIDPTokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		if s, err := p.tokenStore.LoadSession(r); err == nil {
+			ctx = context.WithValue(ctx, idpTokensKey{}, s)
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// ForwardIDPToken is middleware that sets the user's identity provider issued
+// access, or id, token as the request's bearer token. Expired tokens are
+// refreshed by the authenticate service. If the user's tokens are unknown, the
+// user is sent to the authenticate service to retrieve them.
+func (p *Proxy) ForwardIDPToken(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			ctx, span := trace.StartSpan(r.Context(), "proxy.ForwardIDPToken")
+			defer span.End()
+			s, err := sessions.FromContext(ctx)
+			if err != nil {
+				return httputil.NewError(http.StatusUnauthorized, err)
+			}
+			tokens, _ := ctx.Value(idpTokensKey{}).(*sessions.State)
+			if tokens == nil || tokens.Subject != s.Subject {
+				return p.retrieveIDPTokens(w, r, s, errors.New("proxy: no identity provider tokens"))
+			}
+			if !tokens.AccessToken.Valid() {
+				tokens, err = p.idpTokens.refresh(ctx, tokens)
+				if err != nil {
+					log.FromRequest(r).Info().Err(err).Msg("proxy: refresh identity provider tokens")
+					p.tokenStore.ClearSession(w, r)
+					return p.retrieveIDPTokens(w, r, s, err)
+				}
+				if err := p.tokenStore.SaveSession(w, r, tokens); err != nil {
+					return fmt.Errorf("proxy: identity provider tokens save failure: %w", err)
+				}
+			}
+			bearer := tokens.AccessToken.AccessToken
+			if token == config.ForwardIDToken {
+				bearer = tokens.RawIDToken
+			}
+			if bearer == "" {
+				return httputil.NewError(http.StatusBadGateway, fmt.Errorf("proxy: identity provider issued no %s", token))
+			}
+			r.Header.Set("Authorization", "Bearer "+bearer)
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return nil
+		})
+	}
+}
+
+// retrieveIDPTokens sends the user to the authenticate service to sign in
+// and hand over their identity provider tokens. Programmatic sessions, which
+// cannot follow redirects, fail instead.
+func (p *Proxy) retrieveIDPTokens(w http.ResponseWriter, r *http.Request, s *sessions.State, err error) error {
+	if s.Programmatic {
+		return httputil.NewError(http.StatusUnauthorized, err)
+	}
+	p.redirectToSignin(w, r, true)
+	return nil
+}
+
+// idpTokenRefresher refreshes identity provider tokens using the
+// authenticate service. Concurrent refreshes of the same tokens, as from a
+// page's parallel requests, are only made once, as some identity providers
+// only allow each refresh token to be used once.
+type idpTokenRefresher struct {
+	sharedKey string
+	endpoint  *url.URL
+	encoder   encoding.MarshalUnmarshaler
+
+	mu       sync.Mutex
+	inflight map[string]*idpTokenRefresh
+}
+
+type idpTokenRefresh struct {
+	done    chan struct{}
+	expires time.Time
+	tokens  *sessions.State
+	err     error
+}
+
+func newIDPTokenRefresher(sharedKey string, authenticateURL *url.URL, encoder encoding.MarshalUnmarshaler) *idpTokenRefresher {
+	return &idpTokenRefresher{
+		sharedKey: sharedKey,
+		endpoint:  authenticateURL.ResolveReference(&url.URL{Path: tokensURL}),
+		encoder:   encoder,
+		inflight:  make(map[string]*idpTokenRefresh),
+	}
+}
+
+// refresh returns the refreshed tokens, joining any refresh of the same
+// tokens that is in flight or recently done.
+func (rf *idpTokenRefresher) refresh(ctx context.Context, s *sessions.State) (*sessions.State, error) {
+	if s.AccessToken == nil {
+		return nil, errors.New("proxy: identity provider tokens missing")
+	}
+	key := s.AccessToken.AccessToken + "|" + s.AccessToken.RefreshToken
+
+	rf.mu.Lock()
+	now := time.Now()
+	for k, call := range rf.inflight {
+		if !call.expires.IsZero() && now.After(call.expires) {
+			delete(rf.inflight, k)
+		}
+	}
+	call, ok := rf.inflight[key]
+	if !ok {
+		call = &idpTokenRefresh{done: make(chan struct{})}
+		rf.inflight[key] = call
+	}
+	rf.mu.Unlock()
+
+	if ok {
+		select {
+		case <-call.done:
+			return call.tokens, call.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	// the refresh is shared, so must not be cancelled with this request
+	call.tokens, call.err = rf.do(context.Background(), s)
+	rf.mu.Lock()
+	if call.err != nil {
+		delete(rf.inflight, key)
+	} else {
+		call.expires = time.Now().Add(refreshedTokensTTL)
+	}
+	rf.mu.Unlock()
+	close(call.done)
+	return call.tokens, call.err
+}
+
+func (rf *idpTokenRefresher) do(ctx context.Context, s *sessions.State) (*sessions.State, error) {
+	encTokens, err := rf.encoder.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	endpoint := urlutil.NewSignedURL(rf.sharedKey, rf.endpoint).String()
+	params := url.Values{urlutil.QuerySessionTokens: {string(encTokens)}}
+	var response struct {
+		SessionTokens string `json:"session_tokens"`
+	}
+	if err := httputil.Client(ctx, http.MethodPost, endpoint, version.UserAgent(), nil, params, &response); err != nil {
+		return nil, fmt.Errorf("proxy: identity provider token refresh failed: %w", err)
+	}
+	var tokens sessions.State
+	if err := rf.encoder.Unmarshal([]byte(response.SessionTokens), &tokens); err != nil {
+		return nil, fmt.Errorf("proxy: malformed identity provider tokens: %w", err)
+	}
+	return &tokens, nil
+}
@@ -0,0 +1,177 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+// testAuthenticateTokens is a stand-in for authenticate's token refresh
+// endpoint, that counts the refreshes it makes.
+func testAuthenticateTokens(t *testing.T, p *Proxy, refreshed *sessions.State, refreshes *int32) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(refreshes, 1)
+		u := *r.URL
+		u.Scheme, u.Host = "http", r.Host
+		if err := urlutil.NewSignedURL(p.SharedKey, &u).Validate(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if refreshed == nil {
+			http.Error(w, "refresh failed", http.StatusUnauthorized)
+			return
+		}
+		// give concurrent refreshes a chance to overlap
+		time.Sleep(10 * time.Millisecond)
+		enc, err := p.idpTokens.encoder.Marshal(refreshed)
+		if err != nil {
+			t.Error(err)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"session_tokens": string(enc)})
+	}))
+}
+
+func TestProxy_ForwardIDPToken(t *testing.T) {
+	t.Parallel()
+	valid := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)}
+	expired := &oauth2.Token{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}
+	refreshed := &sessions.State{Subject: "user", AccessToken: &oauth2.Token{AccessToken: "refreshed", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)}, RawIDToken: "refreshed.id.token"}
+
+	tests := []struct {
+		name      string
+		token     string
+		session   *sessions.State
+		tokens    *sessions.State
+		refreshed *sessions.State
+
+		wantStatus        int
+		wantAuthorization string
+		wantCookie        bool
+	}{
+		{"access token", "access_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "user", AccessToken: valid, RawIDToken: "id.token"}, nil, http.StatusOK, "Bearer access", false},
+		{"id token", "id_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "user", AccessToken: valid, RawIDToken: "id.token"}, nil, http.StatusOK, "Bearer id.token", false},
+		{"missing id token", "id_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "user", AccessToken: valid}, nil, http.StatusBadGateway, "", false},
+		{"refreshed access token", "access_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "user", AccessToken: expired}, refreshed, http.StatusOK, "Bearer refreshed", true},
+		{"refreshed id token", "id_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "user", AccessToken: expired}, refreshed, http.StatusOK, "Bearer refreshed.id.token", true},
+		{"refresh failed", "access_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "user", AccessToken: expired}, nil, http.StatusFound, "", true},
+		{"no tokens", "access_token", &sessions.State{Subject: "user"}, nil, nil, http.StatusFound, "", false},
+		{"another user's tokens", "access_token", &sessions.State{Subject: "user"}, &sessions.State{Subject: "other", AccessToken: valid}, nil, http.StatusFound, "", false},
+		{"programmatic no tokens", "access_token", &sessions.State{Subject: "user", Programmatic: true}, nil, nil, http.StatusUnauthorized, "", false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := testOptions(t)
+			p, err := New(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var refreshes int32
+			srv := testAuthenticateTokens(t, p, tt.refreshed, &refreshes)
+			defer srv.Close()
+			authenticateURL, _ := url.Parse(srv.URL)
+			p.idpTokens = newIDPTokenRefresher(p.SharedKey, authenticateURL, p.idpTokens.encoder)
+
+			var gotAuthorization string
+			h := p.LoadIDPTokens(p.ForwardIDPToken(tt.token)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotAuthorization = r.Header.Get("Authorization")
+			})))
+
+			r := httptest.NewRequest(http.MethodGet, "https://corp.example.example/", nil)
+			if tt.tokens != nil {
+				w := httptest.NewRecorder()
+				if err := p.tokenStore.SaveSession(w, r, tt.tokens); err != nil {
+					t.Fatal(err)
+				}
+				for _, c := range w.Result().Cookies() {
+					r.AddCookie(c)
+				}
+			}
+			r = r.WithContext(sessions.NewContext(r.Context(), tt.session, nil))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if gotAuthorization != tt.wantAuthorization {
+				t.Errorf("Authorization = %q, want %q", gotAuthorization, tt.wantAuthorization)
+			}
+			if gotCookie := len(w.Result().Cookies()) != 0; gotCookie != tt.wantCookie {
+				t.Errorf("set cookie = %v, want %v", gotCookie, tt.wantCookie)
+			}
+			if tt.wantStatus == http.StatusFound {
+				location, _ := url.Parse(w.Header().Get("Location"))
+				if location.Query().Get(urlutil.QueryForwardTokens) != "true" {
+					t.Errorf("sign in redirect %s does not request tokens", location)
+				}
+			}
+		})
+	}
+}
+
+func Test_idpTokenRefresher(t *testing.T) {
+	t.Parallel()
+	p, err := New(testOptions(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	refreshed := &sessions.State{Subject: "user", AccessToken: &oauth2.Token{AccessToken: "refreshed", Expiry: time.Now().Add(time.Hour)}}
+	var refreshes int32
+	srv := testAuthenticateTokens(t, p, refreshed, &refreshes)
+	defer srv.Close()
+	authenticateURL, _ := url.Parse(srv.URL)
+	rf := newIDPTokenRefresher(p.SharedKey, authenticateURL, p.idpTokens.encoder)
+
+	// parallel requests, and those made with the replaced tokens shortly
+	// after, share a single refresh
+	expired := &sessions.State{Subject: "user", AccessToken: &oauth2.Token{AccessToken: "expired", RefreshToken: "refresh"}}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := rf.refresh(context.Background(), expired)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if got.AccessToken.AccessToken != "refreshed" {
+				t.Errorf("access token = %q, want %q", got.AccessToken.AccessToken, "refreshed")
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := rf.refresh(context.Background(), expired); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 1 {
+		t.Errorf("refreshes = %d, want 1", n)
+	}
+
+	// other tokens are refreshed separately
+	other := &sessions.State{Subject: "other", AccessToken: &oauth2.Token{AccessToken: "other", RefreshToken: "other"}}
+	if _, err := rf.refresh(context.Background(), other); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&refreshes); n != 2 {
+		t.Errorf("refreshes = %d, want 2", n)
+	}
+
+	if _, err := rf.refresh(context.Background(), &sessions.State{}); err == nil {
+		t.Error("expected an error refreshing a session without tokens")
+	}
+}
@@ -0,0 +1,66 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/telemetry/trace"
+)
+
+const (
+	// HeaderImpersonateUser is the header key Kubernetes reads the user to
+	// act as from.
+	HeaderImpersonateUser = "Impersonate-User"
+	// HeaderImpersonateGroup is the header key, repeated for each group,
+	// Kubernetes reads the groups to act as from.
+	HeaderImpersonateGroup = "Impersonate-Group"
+
+	// impersonatePrefix prefixes all Kubernetes impersonation headers,
+	// including `Impersonate-Uid` and `Impersonate-Extra-*`.
+	impersonatePrefix = "Impersonate-"
+)
+
+// KubernetesImpersonation is middleware that authenticates requests to a
+// Kubernetes API server with a service account's bearer token, and has the
+// API server act as the user making the request, and their groups, using
+// impersonation headers. Any impersonation headers sent by the client are
+// removed first.
+//
+// https://kubernetes.io/docs/reference/access-authn-authz/authentication/#user-impersonation
+func KubernetesImpersonation(token string) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			ctx, span := trace.StartSpan(r.Context(), "proxy.KubernetesImpersonation")
+			defer span.End()
+			for key := range r.Header {
+				if strings.HasPrefix(key, impersonatePrefix) {
+					r.Header.Del(key)
+				}
+			}
+			s, err := sessions.FromContext(ctx)
+			if err != nil {
+				return httputil.NewError(http.StatusUnauthorized, err)
+			}
+			user := s.RequestEmail()
+			if user == "" {
+				return httputil.NewError(http.StatusForbidden, errors.New("proxy: session has no email to impersonate"))
+			}
+			r.Header.Set("Authorization", "Bearer "+token)
+			r.Header.Set(HeaderImpersonateUser, user)
+			// groups are ranged over, rather than split from RequestGroups, as
+			// group names, such as distinguished names, may contain commas
+			groups := s.Groups
+			if len(s.ImpersonateGroups) != 0 {
+				groups = s.ImpersonateGroups
+			}
+			for _, group := range groups {
+				r.Header.Add(HeaderImpersonateGroup, group)
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return nil
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/pomerium/pomerium/internal/sessions"
+)
+
+func TestKubernetesImpersonation(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		session    *sessions.State
+		sessionErr error
+		headers    map[string]string
+
+		wantStatus int
+		wantUser   string
+		wantGroups []string
+	}{
+		{"user and groups", &sessions.State{Email: "user@example.com", Groups: []string{"admins", "devs"}}, nil, nil, http.StatusOK, "user@example.com", []string{"admins", "devs"}},
+		{"no groups", &sessions.State{Email: "user@example.com"}, nil, nil, http.StatusOK, "user@example.com", nil},
+		{"group with a comma", &sessions.State{Email: "user@example.com", Groups: []string{"cn=admins,ou=groups", "devs"}}, nil, nil, http.StatusOK, "user@example.com", []string{"cn=admins,ou=groups", "devs"}},
+		{"impersonating", &sessions.State{Email: "admin@example.com", Groups: []string{"admins"}, ImpersonateEmail: "user@example.com", ImpersonateGroups: []string{"devs"}}, nil, nil, http.StatusOK, "user@example.com", []string{"devs"}},
+		{"client impersonation stripped", &sessions.State{Email: "user@example.com"}, nil, map[string]string{"Impersonate-User": "system:admin", "Impersonate-Group": "system:masters", "Impersonate-Uid": "0", "Impersonate-Extra-Scopes": "all"}, http.StatusOK, "user@example.com", nil},
+		{"no email", &sessions.State{Subject: "user"}, nil, nil, http.StatusForbidden, "", nil},
+		{"no session", nil, errors.New("error"), nil, http.StatusUnauthorized, "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got http.Header
+			h := KubernetesImpersonation("service-account-token")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.Header
+			}))
+			r := httptest.NewRequest(http.MethodGet, "/api/v1/namespaces", nil)
+			r.Header.Set("Authorization", "Pomerium session")
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			r = r.WithContext(sessions.NewContext(r.Context(), tt.session, tt.sessionErr))
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+			want := http.Header{
+				"Authorization":    {"Bearer service-account-token"},
+				"Impersonate-User": {tt.wantUser},
+			}
+			if tt.wantGroups != nil {
+				want["Impersonate-Group"] = tt.wantGroups
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("KubernetesImpersonation() headers = %s", diff)
+			}
+		})
+	}
+}
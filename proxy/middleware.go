@@ -1,7 +1,9 @@
 package proxy // import "github.com/pomerium/pomerium/proxy"
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	"github.com/pomerium/pomerium/internal/encoding"
@@ -36,11 +38,8 @@ func (p *Proxy) AuthenticateSession(next http.Handler) http.Handler {
 			if s != nil && s.Programmatic {
 				return httputil.NewError(http.StatusUnauthorized, err)
 			}
-			signinURL := *p.authenticateSigninURL
-			q := signinURL.Query()
-			q.Set(urlutil.QueryRedirectURI, urlutil.GetAbsoluteURL(r).String())
-			signinURL.RawQuery = q.Encode()
-			httputil.Redirect(w, r, urlutil.NewSignedURL(p.SharedKey, &signinURL).String(), http.StatusFound)
+			p.redirectToSignin(w, r, false)
+			return nil
 		}
 		p.addPomeriumHeaders(w, r)
 		next.ServeHTTP(w, r.WithContext(ctx))
@@ -49,6 +48,20 @@ func (p *Proxy) AuthenticateSession(next http.Handler) http.Handler {
 
 }
 
+// redirectToSignin sends the user to the authenticate service to sign in,
+// and then back to the requested url. If forwardTokens is set, the
+// authenticate service also hands over the user's identity provider tokens.
+func (p *Proxy) redirectToSignin(w http.ResponseWriter, r *http.Request, forwardTokens bool) {
+	signinURL := *p.authenticateSigninURL
+	q := signinURL.Query()
+	q.Set(urlutil.QueryRedirectURI, urlutil.GetAbsoluteURL(r).String())
+	if forwardTokens {
+		q.Set(urlutil.QueryForwardTokens, "true")
+	}
+	signinURL.RawQuery = q.Encode()
+	httputil.RedirectToSignIn(w, r, urlutil.NewSignedURL(p.SharedKey, &signinURL).String())
+}
+
 func (p *Proxy) addPomeriumHeaders(w http.ResponseWriter, r *http.Request) {
 	s, err := sessions.FromContext(r.Context())
 	if err == nil && s != nil {
@@ -67,7 +80,7 @@ func (p *Proxy) AuthorizeSession(next http.Handler) http.Handler {
 	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
 		ctx, span := trace.StartSpan(r.Context(), "proxy.AuthorizeSession")
 		defer span.End()
-		if err := p.authorize(r.Host, r.WithContext(ctx)); err != nil {
+		if err := p.authorize(p.routeID(r.Host, r.URL.Path), r.WithContext(ctx)); err != nil {
 			log.FromRequest(r).Debug().Err(err).Msg("proxy: AuthorizeSession")
 			return err
 		}
@@ -76,16 +89,27 @@ func (p *Proxy) AuthorizeSession(next http.Handler) http.Handler {
 	})
 }
 
-func (p *Proxy) authorize(host string, r *http.Request) error {
+// routeID returns the id of the route a request to host and path is matched
+// to, which it is authorized by, or host if there is no such route.
+func (p *Proxy) routeID(host, path string) string {
+	for i := range p.routes {
+		if p.routes[i].Source.Host == host && p.routes[i].MatchesPath(path) {
+			return p.routes[i].RouteID()
+		}
+	}
+	return host
+}
+
+func (p *Proxy) authorize(route string, r *http.Request) error {
 	s, err := sessions.FromContext(r.Context())
 	if err != nil {
 		return httputil.NewError(http.StatusUnauthorized, err)
 	}
-	authorized, err := p.AuthorizeClient.Authorize(r.Context(), host, s)
+	authorized, err := p.AuthorizeClient.Authorize(r.Context(), route, s)
 	if err != nil {
 		return err
 	} else if !authorized {
-		return httputil.NewError(http.StatusUnauthorized, fmt.Errorf("%s is not authorized for %s", s.RequestEmail(), host))
+		return httputil.NewError(http.StatusUnauthorized, fmt.Errorf("%s is not authorized for %s", s.RequestEmail(), route))
 	}
 	return nil
 }
@@ -115,11 +139,11 @@ func (p *Proxy) SignRequest(signer encoding.Marshaler) func(next http.Handler) h
 	}
 }
 
-// SetResponseHeaders sets a map of response headers.
-func SetResponseHeaders(headers map[string]string) func(next http.Handler) http.Handler {
+// SetRequestHeaders sets a map of request headers.
+func SetRequestHeaders(headers map[string]string) func(next http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx, span := trace.StartSpan(r.Context(), "proxy.SetResponseHeaders")
+			ctx, span := trace.StartSpan(r.Context(), "proxy.SetRequestHeaders")
 			defer span.End()
 			for key, val := range headers {
 				r.Header.Set(key, val)
@@ -128,3 +152,53 @@ func SetResponseHeaders(headers map[string]string) func(next http.Handler) http.
 		})
 	}
 }
+
+// errRequestBodyTooLarge is returned when reading a request body that exceeds
+// a route's size limit.
+var errRequestBodyTooLarge = errors.New("proxy: request body too large")
+
+// LimitRequestBody rejects requests with a body larger than limit bytes.
+// Requests declaring a larger Content-Length fail immediately, while streamed
+// bodies fail once the limit has been read.
+func LimitRequestBody(limit int64) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+			ctx, span := trace.StartSpan(r.Context(), "proxy.LimitRequestBody")
+			defer span.End()
+			if r.ContentLength > limit {
+				return httputil.NewError(http.StatusRequestEntityTooLarge, errRequestBodyTooLarge)
+			}
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = &maxBytesReader{ReadCloser: r.Body, remaining: limit}
+			}
+			next.ServeHTTP(w, r.WithContext(ctx))
+			return nil
+		})
+	}
+}
+
+// maxBytesReader returns errRequestBodyTooLarge once more than its remaining
+// bytes have been read.
+type maxBytesReader struct {
+	io.ReadCloser
+	remaining int64
+}
+
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	if r.remaining < 0 {
+		return 0, errRequestBodyTooLarge
+	}
+	// read one byte past the limit to tell an oversized body from one that
+	// is exactly the limit
+	if int64(len(p)) > r.remaining+1 {
+		p = p[:r.remaining+1]
+	}
+	n, err := r.ReadCloser.Read(p)
+	if int64(n) <= r.remaining {
+		r.remaining -= int64(n)
+		return n, err
+	}
+	n = int(r.remaining)
+	r.remaining = -1
+	return n, errRequestBodyTooLarge
+}
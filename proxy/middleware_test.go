@@ -3,13 +3,17 @@ package proxy
 import (
 	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/pomerium/pomerium/internal/httputil"
 	"github.com/pomerium/pomerium/internal/identity"
 	"github.com/pomerium/pomerium/internal/sessions"
 	"github.com/pomerium/pomerium/proxy/clients"
@@ -65,6 +69,32 @@ func TestProxy_AuthenticateSession(t *testing.T) {
 	}
 }
 
+func TestProxy_AuthenticateSession_redirect(t *testing.T) {
+	t.Parallel()
+	called := false
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	})
+	a := Proxy{
+		SharedKey:             "80ldlrU2d7w+wVpKNfevk6fmb8otEx6CqOfshj2LwhQ=",
+		cookieSecret:          []byte("80ldlrU2d7w+wVpKNfevk6fmb8otEx6CqOfshj2LwhQ="),
+		authenticateURL:       uriParseHelper("https://authenticate.corp.example"),
+		authenticateSigninURL: uriParseHelper("https://authenticate.corp.example/sign_in"),
+		sessionStore:          &sessions.MockSessionStore{},
+	}
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(sessions.NewContext(r.Context(), nil, sessions.ErrNoSessionFound))
+	w := httptest.NewRecorder()
+	a.AuthenticateSession(next).ServeHTTP(w, r)
+	if w.Code != http.StatusFound {
+		t.Errorf("AuthenticateSession() status = %v, want %v", w.Code, http.StatusFound)
+	}
+	// requests without a valid session never reach the upstream
+	if called {
+		t.Error("AuthenticateSession() called next after redirecting to sign in")
+	}
+}
+
 func TestProxy_AuthorizeSession(t *testing.T) {
 	t.Parallel()
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -177,7 +207,7 @@ func TestProxy_SignRequest(t *testing.T) {
 	}
 }
 
-func TestProxy_SetResponseHeaders(t *testing.T) {
+func TestProxy_SetRequestHeaders(t *testing.T) {
 	t.Parallel()
 	fn := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
@@ -204,7 +234,7 @@ func TestProxy_SetResponseHeaders(t *testing.T) {
 
 			r := httptest.NewRequest(http.MethodGet, "/", nil)
 			w := httptest.NewRecorder()
-			got := SetResponseHeaders(tt.setHeaders)(fn)
+			got := SetRequestHeaders(tt.setHeaders)(fn)
 			got.ServeHTTP(w, r)
 			if diff := cmp.Diff(w.Body.String(), tt.wantHeaders); diff != "" {
 				t.Errorf("SignRequest() :\n %s", diff)
@@ -212,3 +242,49 @@ func TestProxy_SetResponseHeaders(t *testing.T) {
 		})
 	}
 }
+
+func TestLimitRequestBody(t *testing.T) {
+	t.Parallel()
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Write(body)
+	}))
+	defer backend.Close()
+	backendURL, _ := url.Parse(backend.URL)
+	proxy := httputil.NewReverseProxy(backendURL)
+	proxy.ErrorHandler = errorHandler
+	h := LimitRequestBody(5)(proxy)
+
+	tests := []struct {
+		name          string
+		body          string
+		contentLength int64
+		wantStatus    int
+	}{
+		{"no body", "", 0, http.StatusOK},
+		{"under limit", "hi", 2, http.StatusOK},
+		{"at limit", "hello", 5, http.StatusOK},
+		{"over limit", "hello world", 11, http.StatusRequestEntityTooLarge},
+		{"streamed under limit", "hello", -1, http.StatusOK},
+		{"streamed over limit", "hello world", -1, http.StatusRequestEntityTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var body io.Reader
+			if tt.body != "" {
+				// hide the reader's type, so that its length is unknown
+				body = ioutil.NopCloser(strings.NewReader(tt.body))
+			}
+			r := httptest.NewRequest(http.MethodPost, "/", body)
+			r.ContentLength = tt.contentLength
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("LimitRequestBody() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusOK && w.Body.String() != tt.body {
+				t.Errorf("LimitRequestBody() body = %q, want %q", w.Body.String(), tt.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+// mirrorRoundTripper returns a tripper that copies a sample of requests to a
+// route's shadow upstream. Credentials, and the given headers the route sets,
+// are removed from mirrored requests.
+func mirrorRoundTripper(settings config.Mirror, removeHeaders []string) tripper.Constructor {
+	m := newMirror(settings, removeHeaders)
+	return func(next http.RoundTripper) http.RoundTripper {
+		return tripper.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			return m.roundTrip(next, req)
+		})
+	}
+}
+
+// mirror sends copies of requests to a shadow upstream in the background.
+type mirror struct {
+	settings  config.Mirror
+	transport http.RoundTripper
+	// sem holds a token for each mirrored request in flight
+	sem chan struct{}
+	// sample reports whether a request should be mirrored
+	sample func() bool
+	// removeHeaders are not sent to the mirror
+	removeHeaders []string
+}
+
+// mirrorRemoveHeaders are the credentials, whether the client's or added by
+// the route, that are never sent to a mirror.
+var mirrorRemoveHeaders = []string{"Authorization", "Cookie", HeaderJWT}
+
+func newMirror(settings config.Mirror, removeHeaders []string) *mirror {
+	percent := float64(config.DefaultMirrorPercent)
+	if settings.Percent != nil {
+		percent = *settings.Percent
+	}
+	return &mirror{
+		settings:      settings,
+		removeHeaders: append(append([]string(nil), mirrorRemoveHeaders...), removeHeaders...),
+		transport: tripper.NewChain(metrics.HTTPMetricsRoundTripper("proxy", settings.Destination.Host)).
+			Then(http.DefaultTransport.(*http.Transport).Clone()),
+		sem: make(chan struct{}, settings.MaxConcurrentRequests),
+		sample: func() bool {
+			return rand.Float64()*100 < percent
+		},
+	}
+}
+
+// roundTrip sends the request upstream and, if it is sampled, mirrors it
+// once its body has been sent. The request is only mirrored if its whole
+// body was read by the upstream's transport, so the body is never read on
+// the mirror's behalf.
+func (m *mirror) roundTrip(next http.RoundTripper, req *http.Request) (*http.Response, error) {
+	// upgraded connections, such as websockets, are not mirrored
+	if req.Header.Get("Upgrade") != "" || !m.sample() {
+		return next.RoundTrip(req)
+	}
+	// the request may be changed by the transport, so copy it now
+	mreq, err := m.newRequest(req)
+	if err != nil {
+		log.FromRequest(req).Debug().Err(err).Msg("proxy: could not mirror request")
+		return next.RoundTrip(req)
+	}
+	if req.Body == nil || req.Body == http.NoBody {
+		m.send(mreq, nil)
+		return next.RoundTrip(req)
+	}
+	r := *req
+	r.Body = &teeReadCloser{
+		ReadCloser: req.Body,
+		max:        m.settings.MaxBodyBytes,
+		done:       func(body []byte) { m.send(mreq, body) },
+	}
+	return next.RoundTrip(&r)
+}
+
+// newRequest copies req, as it would be sent upstream, to the mirror, without
+// its credentials.
+func (m *mirror) newRequest(req *http.Request) (*http.Request, error) {
+	u := *m.settings.Destination
+	u.Path = strings.TrimSuffix(u.Path, "/") + req.URL.Path
+	u.RawPath = ""
+	u.RawQuery = req.URL.RawQuery
+	mreq, err := http.NewRequest(req.Method, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	mreq.Header = req.Header.Clone()
+	for _, key := range m.removeHeaders {
+		mreq.Header.Del(key)
+	}
+	return mreq, nil
+}
+
+// send mirrors the request in the background, unless too many mirrored
+// requests are already in flight. The mirror's response is discarded.
+func (m *mirror) send(mreq *http.Request, body []byte) {
+	select {
+	case m.sem <- struct{}{}:
+	default:
+		log.Debug().Str("mirror", m.settings.Destination.Host).Msg("proxy: too many mirrored requests in flight")
+		return
+	}
+	if body != nil {
+		mreq.Body = ioutil.NopCloser(bytes.NewReader(body))
+		mreq.ContentLength = int64(len(body))
+	}
+	go func() {
+		defer func() { <-m.sem }()
+		ctx, cancel := context.WithTimeout(context.Background(), m.settings.Timeout)
+		defer cancel()
+		res, err := m.transport.RoundTrip(mreq.WithContext(ctx))
+		if err != nil {
+			log.Debug().Err(err).Str("mirror", m.settings.Destination.Host).Msg("proxy: mirrored request failed")
+			return
+		}
+		io.Copy(ioutil.Discard, res.Body)
+		res.Body.Close()
+	}()
+}
+
+// teeReadCloser keeps a copy of up to max bytes read from its ReadCloser. If
+// it is read to the end without exceeding max, done is called, once, with
+// the bytes read.
+type teeReadCloser struct {
+	io.ReadCloser
+	max  int64
+	done func(body []byte)
+
+	buf      bytes.Buffer
+	overflow bool
+	once     sync.Once
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if !t.overflow {
+		if int64(t.buf.Len()+n) > t.max {
+			t.overflow = true
+			t.buf = bytes.Buffer{}
+		} else {
+			t.buf.Write(p[:n])
+		}
+	}
+	if err == io.EOF && !t.overflow {
+		t.once.Do(func() { t.done(t.buf.Bytes()) })
+	}
+	return n, err
+}
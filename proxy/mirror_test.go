@@ -0,0 +1,159 @@
+package proxy
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+type mirroredRequest struct {
+	method, uri, header, body string
+	// credentials are the values of any credential headers sent
+	credentials string
+}
+
+func TestMirror(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		method   string
+		body     string
+		sampled  bool
+		inflight int
+
+		want *mirroredRequest
+	}{
+		{"get", http.MethodGet, "", true, 0, &mirroredRequest{http.MethodGet, "/shadow/api?q=1", "value", "", ""}},
+		{"post", http.MethodPost, "hello", true, 0, &mirroredRequest{http.MethodPost, "/shadow/api?q=1", "value", "hello", ""}},
+		{"body too large", http.MethodPost, strings.Repeat("a", 11), true, 0, nil},
+		{"not sampled", http.MethodGet, "", false, 0, nil},
+		{"too many in flight", http.MethodGet, "", true, 1, nil},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			mirrored := make(chan mirroredRequest, 1)
+			shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := ioutil.ReadAll(r.Body)
+				credentials := r.Header.Get("Authorization") + r.Header.Get("Cookie") + r.Header.Get(HeaderJWT) + r.Header.Get("X-Secret")
+				mirrored <- mirroredRequest{r.Method, r.URL.RequestURI(), r.Header.Get("X-Test"), string(body), credentials}
+				http.Error(w, "shadow", http.StatusInternalServerError)
+			}))
+			defer shadow.Close()
+			destination, _ := url.Parse(shadow.URL + "/shadow/")
+
+			m := newMirror(config.Mirror{To: shadow.URL, Destination: destination, MaxConcurrentRequests: 1, MaxBodyBytes: 10, Timeout: time.Second}, []string{"X-Secret"})
+			m.sample = func() bool { return tt.sampled }
+			for i := 0; i < tt.inflight; i++ {
+				m.sem <- struct{}{}
+			}
+			upstream := tripper.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if req.Body != nil {
+					ioutil.ReadAll(req.Body)
+					req.Body.Close()
+				}
+				return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+			})
+
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req := httptest.NewRequest(tt.method, "https://upstream.example/api?q=1", body)
+			req.Header.Set("X-Test", "value")
+			// credentials, and headers set by the route, are not mirrored
+			req.Header.Set("Authorization", "Bearer token")
+			req.Header.Set("Cookie", "session=cookie")
+			req.Header.Set(HeaderJWT, "jwt")
+			req.Header.Set("X-Secret", "secret")
+			res, err := m.roundTrip(upstream, req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.StatusCode != http.StatusOK {
+				t.Errorf("status = %d, want %d", res.StatusCode, http.StatusOK)
+			}
+
+			select {
+			case got := <-mirrored:
+				if tt.want == nil {
+					t.Fatalf("unexpected mirrored request %+v", got)
+				}
+				if got != *tt.want {
+					t.Errorf("mirrored request = %+v, want %+v", got, *tt.want)
+				}
+			case <-time.After(200 * time.Millisecond):
+				if tt.want != nil {
+					t.Fatal("request was not mirrored")
+				}
+			}
+		})
+	}
+}
+
+func TestMirror_slowShadow(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	shadow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer shadow.Close()
+	defer close(release)
+	destination, _ := url.Parse(shadow.URL)
+
+	m := newMirror(config.Mirror{To: shadow.URL, Destination: destination, MaxConcurrentRequests: 10, MaxBodyBytes: 10, Timeout: time.Minute}, nil)
+	upstream := tripper.RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+	})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := m.roundTrip(upstream, httptest.NewRequest(http.MethodGet, "https://upstream.example/", nil)); err != nil {
+			t.Error(err)
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("primary request waited on the mirror")
+	}
+}
+
+func TestMirror_percent(t *testing.T) {
+	t.Parallel()
+	destination, _ := url.Parse("https://shadow.example")
+	zero, half := 0.0, 50.0
+	tests := []struct {
+		name        string
+		percent     *float64
+		wantSampled bool
+		wantSkipped bool
+	}{
+		{"default", nil, true, false},
+		{"zero mirrors nothing", &zero, false, true},
+		{"half", &half, true, true},
+	}
+	for _, tt := range tests {
+		m := newMirror(config.Mirror{Destination: destination, Percent: tt.percent, MaxConcurrentRequests: 1}, nil)
+		var sampled, skipped bool
+		for i := 0; i < 1000; i++ {
+			if m.sample() {
+				sampled = true
+			} else {
+				skipped = true
+			}
+		}
+		if sampled != tt.wantSampled || skipped != tt.wantSkipped {
+			t.Errorf("%s: sampled = %v, skipped = %v", tt.name, sampled, skipped)
+		}
+	}
+}
@@ -1,13 +1,17 @@
 package proxy // import "github.com/pomerium/pomerium/proxy"
 
 import (
+	"context"
 	"crypto/cipher"
 	"crypto/tls"
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"html/template"
+	"net"
 	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/gorilla/mux"
@@ -15,6 +19,7 @@ import (
 	"github.com/pomerium/pomerium/config"
 	"github.com/pomerium/pomerium/internal/cryptutil"
 	"github.com/pomerium/pomerium/internal/encoding"
+	"github.com/pomerium/pomerium/internal/encoding/ecjson"
 	"github.com/pomerium/pomerium/internal/encoding/jws"
 	"github.com/pomerium/pomerium/internal/frontend"
 	"github.com/pomerium/pomerium/internal/httputil"
@@ -34,6 +39,9 @@ const (
 	signinURL = "/.pomerium/sign_in"
 	// signoutURL is the path to authenticate's sign out endpoint
 	signoutURL = "/.pomerium/sign_out"
+	// forwardAuthPrefix prefixes the path of requests to verify sent by
+	// trusted proxies that keep the original request's host
+	forwardAuthPrefix = "/.pomerium/verify"
 )
 
 // ValidateOptions checks that proper configuration settings are set to create
@@ -76,16 +84,38 @@ type Proxy struct {
 
 	AuthorizeClient clients.Authorizer
 
-	encoder                encoding.Unmarshaler
-	cookieOptions          *sessions.CookieOptions
-	cookieSecret           []byte
-	defaultUpstreamTimeout time.Duration
-	refreshCooldown        time.Duration
-	Handler                http.Handler
-	sessionStore           sessions.SessionStore
-	sessionLoaders         []sessions.SessionLoader
-	signingKey             string
-	templates              *template.Template
+	encoder                    encoding.Unmarshaler
+	cookieOptions              *sessions.CookieOptions
+	cookieSecret               []byte
+	defaultUpstreamTimeout     time.Duration
+	defaultMaxRequestBodyBytes int64
+	refreshCooldown            time.Duration
+	Handler                    http.Handler
+	sessionStore               sessions.SessionStore
+	sessionLoaders             []sessions.SessionLoader
+	inventory                  *sessionInventory
+	stopInventory              context.CancelFunc
+	tokenStore                 sessions.SessionStore
+	idpTokens                  *idpTokenRefresher
+	signingKey                 string
+	templates                  *template.Template
+	templatesDir               string
+	trustedProxies             []*net.IPNet
+	// routes are the validated policies, in the order requests are matched
+	// to them
+	routes []config.Policy
+	// verifyRoutes matches the urls forward-auth verifies to the headers
+	// their routes send upstream
+	verifyRoutes *mux.Router
+	// revokedServiceAccounts are the ids of configured revoked service
+	// accounts
+	revokedServiceAccounts *sessions.RevocationList
+	// jwks is the json encoded public key set of the signing key
+	jwks []byte
+	// circuitBreakers are shared by routes, and kept across reloads
+	circuitBreakers *circuitBreakers
+	// customTemplates are the routes' templates, kept across reloads
+	customTemplates *httputil.TemplatesCache
 }
 
 // New takes a Proxy service from options and a validation function.
@@ -116,29 +146,64 @@ func New(opts config.Options) (*Proxy, error) {
 	if err != nil {
 		return nil, err
 	}
+	// sessions revoked with the authenticate service are rejected
+	authenticateURL, _ := urlutil.DeepCopy(opts.AuthenticateURL)
+	inventory := newSessionInventory(opts.SharedKey, authenticateURL)
+	sessionStore := sessions.NewRevocationStore(cookieStore, inventory)
+	// service accounts, loaded from the header or query string, can also be
+	// revoked in the configuration
+	revokedServiceAccounts := sessions.NewRevocationList(opts.RevokedServiceAccounts)
+	programmaticLoader := func(l sessions.SessionLoader) sessions.SessionLoader {
+		return sessions.NewRevocationLoader(sessions.NewRevocationLoader(l, inventory), revokedServiceAccounts)
+	}
+
+	// identity provider tokens, forwarded to some upstreams, are encrypted
+	// with the shared key so that they can be refreshed by authenticate
+	tokenEncoder := ecjson.New(sharedCipher)
+	tokenCookieOptions := *cookieOptions
+	tokenCookieOptions.Name = fmt.Sprintf("%s_idp", cookieOptions.Name)
+	tokenStore, err := sessions.NewCookieStore(&tokenCookieOptions, tokenEncoder)
+	if err != nil {
+		return nil, err
+	}
+
+	// errors checked in ValidateOptions
+	jwks, _ := jws.PublicJWKSet(opts.SigningKey)
+	encodedJWKS, err := json.Marshal(jwks)
+	if err != nil {
+		return nil, err
+	}
 
 	p := &Proxy{
 		SharedKey:    opts.SharedKey,
 		sharedCipher: sharedCipher,
 		encoder:      encoder,
 
-		cookieSecret:           decodedCookieSecret,
-		cookieOptions:          cookieOptions,
-		defaultUpstreamTimeout: opts.DefaultUpstreamTimeout,
-		refreshCooldown:        opts.RefreshCooldown,
-		sessionStore:           cookieStore,
+		cookieSecret:               decodedCookieSecret,
+		cookieOptions:              cookieOptions,
+		defaultUpstreamTimeout:     opts.DefaultUpstreamTimeout,
+		defaultMaxRequestBodyBytes: opts.DefaultMaxRequestBodyBytes,
+		refreshCooldown:            opts.RefreshCooldown,
+		sessionStore:               sessionStore,
 		sessionLoaders: []sessions.SessionLoader{
-			cookieStore,
-			sessions.NewHeaderStore(encoder, "Pomerium"),
-			sessions.NewQueryParamStore(encoder, "pomerium_session")},
-		signingKey: opts.SigningKey,
-		templates:  template.Must(frontend.NewTemplates()),
+			sessionStore,
+			programmaticLoader(sessions.NewHeaderStore(encoder, "Pomerium")),
+			programmaticLoader(sessions.NewQueryParamStore(encoder, "pomerium_session"))},
+		inventory:              inventory,
+		revokedServiceAccounts: revokedServiceAccounts,
+		tokenStore:             tokenStore,
+		signingKey:             opts.SigningKey,
+		templates:              template.Must(frontend.NewTemplates()),
+		jwks:                   encodedJWKS,
+		circuitBreakers:        newCircuitBreakers(),
+		customTemplates:        httputil.NewTemplatesCache(),
 	}
 	// errors checked in ValidateOptions
 	p.authorizeURL, _ = urlutil.DeepCopy(opts.AuthorizeURL)
 	p.authenticateURL, _ = urlutil.DeepCopy(opts.AuthenticateURL)
 	p.authenticateSigninURL = p.authenticateURL.ResolveReference(&url.URL{Path: signinURL})
 	p.authenticateSignoutURL = p.authenticateURL.ResolveReference(&url.URL{Path: signoutURL})
+	p.idpTokens = newIDPTokenRefresher(p.SharedKey, p.authenticateURL, tokenEncoder)
 
 	if err := p.UpdatePolicies(&opts); err != nil {
 		return nil, err
@@ -157,7 +222,27 @@ func New(opts config.Options) (*Proxy, error) {
 			ClientDNSRoundRobin:     opts.GRPCClientDNSRoundRobin,
 			WithInsecure:            opts.GRPCInsecure,
 		})
-	return p, err
+	if err != nil {
+		return p, err
+	}
+	// revoked sessions are fetched in the background until the proxy is
+	// closed
+	ctx, stopInventory := context.WithCancel(context.Background())
+	p.stopInventory = stopInventory
+	go p.inventory.run(ctx)
+	return p, nil
+}
+
+// Close stops the proxy's background work, and closes its connection to the
+// authorize service.
+func (p *Proxy) Close() error {
+	if p.stopInventory != nil {
+		p.stopInventory()
+	}
+	if p.AuthorizeClient == nil {
+		return nil
+	}
+	return p.AuthorizeClient.Close()
 }
 
 // UpdateOptions updates internal structures based on config.Options
@@ -182,6 +267,19 @@ func (p *Proxy) UpdatePolicies(opts *config.Options) error {
 	r.SkipClean(true)
 	r.StrictSlash(true)
 	r.HandleFunc("/robots.txt", p.RobotsTxt).Methods(http.MethodGet)
+	// the signing key's public key set is published on every route, for
+	// upstreams to verify identity headers with
+	r.HandleFunc("/.well-known/pomerium/jwks.json", p.JWKS).Methods(http.MethodGet)
+	p.trustedProxies = opts.ForwardAuthTrustedProxyCIDRs
+	if authenticateURL, err := urlutil.DeepCopy(opts.AuthenticateURL); err == nil && authenticateURL != nil {
+		p.inventory.setAuthenticateURL(authenticateURL)
+	}
+	p.revokedServiceAccounts.Set(opts.RevokedServiceAccounts)
+	if opts.ForwardAuthURL != nil && len(p.trustedProxies) != 0 {
+		// trusted proxies may verify requests on any host, before the
+		// dashboard handlers claim the path
+		r = p.registerFwdAuthPrefixHandlers(r)
+	}
 	// dashboard handlers are registered to all routes
 	r = p.registerDashboardHandlers(r)
 
@@ -191,82 +289,316 @@ func (p *Proxy) UpdatePolicies(opts *config.Options) error {
 		h.PathPrefix("/").Handler(p.registerFwdAuthHandlers())
 	}
 
-	for _, policy := range opts.Policies {
+	p.templatesDir = opts.TemplatesDir
+	verifyRoutes := httputil.NewRouter()
+	p.circuitBreakers.load()
+	p.customTemplates.BeginReload()
+	routes := sortRoutes(opts.Policies)
+	for i := range routes {
+		policy := &routes[i]
 		if err := policy.Validate(); err != nil {
 			return fmt.Errorf("proxy: invalid policy %w", err)
 		}
-		r, err = p.reverseProxyHandler(r, &policy)
+		r, err = p.reverseProxyHandler(r, policy)
 		if err != nil {
 			return err
 		}
+		if err := p.registerVerifyRoute(verifyRoutes, policy); err != nil {
+			return err
+		}
 	}
+	p.circuitBreakers.loaded()
+	p.customTemplates.EndReload()
+	p.routes = routes
+	p.verifyRoutes = verifyRoutes
 	p.Handler = r
 	return nil
 }
 
+// sortRoutes returns a copy of policies, ordered so that routes with longer
+// path prefixes are matched before the other routes on their host.
+func sortRoutes(policies []config.Policy) []config.Policy {
+	routes := append([]config.Policy(nil), policies...)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return len(routes[i].Prefix) > len(routes[j].Prefix)
+	})
+	return routes
+}
+
+// matchPath returns a matcher for the requests within a route's path prefix.
+func matchPath(policy *config.Policy) mux.MatcherFunc {
+	return func(r *http.Request, _ *mux.RouteMatch) bool {
+		return policy.MatchesPath(r.URL.Path)
+	}
+}
+
 func (p *Proxy) reverseProxyHandler(r *mux.Router, policy *config.Policy) (*mux.Router, error) {
-	// 1. Create the reverse proxy connection
-	proxy := httputil.NewReverseProxy(policy.Destination)
-	// 2. Override any custom transport settings (e.g. TLS settings, etc)
-	proxy.Transport = p.roundTripperFromPolicy(policy)
+	timeout := p.defaultUpstreamTimeout
+	if policy.UpstreamTimeout != 0 {
+		timeout = policy.UpstreamTimeout
+	}
+	// 1. Create the reverse proxy connection or, for TCP routes, the tunnel.
+	// Static routes reply to requests themselves, and routes with destination
+	// groups pick a reverse proxy per user.
+	var handler http.Handler
+	switch {
+	case policy.Redirect != nil:
+		handler = redirectHandler(policy.Redirect)
+	case policy.Response != nil:
+		handler = staticResponseHandler(policy.Response)
+	case policy.IsTCP():
+		handler = tcpTunnel(policy.Destination.Host, timeout)
+	case len(policy.DestinationGroups) != 0:
+		var err error
+		handler, err = p.destinationGroupsHandler(policy, func(gp *config.Policy) (http.Handler, error) {
+			h, err := p.signForDestination(gp, p.reverseProxy(gp))
+			if err != nil {
+				return nil, err
+			}
+			if hasUpstreamTimeout(gp) {
+				h = upstreamTimeout(gp, timeout)(h)
+			}
+			return h, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	default:
+		handler = p.reverseProxy(policy)
+	}
 	// 3. Create a sub-router for a given route's hostname (`httpbin.corp.example.com`)
+	// and, optionally, path prefix (`/grafana`). TCP routes only accept CONNECT requests.
 	rp := r.Host(policy.Source.Host).Subrouter()
-	rp.PathPrefix("/").Handler(proxy)
-
-	// Optional: If websockets are enabled, do not set a handler request timeout
-	// websockets cannot use the non-hijackable timeout-handler
-	if !policy.AllowWebsockets {
-		timeout := p.defaultUpstreamTimeout
-		if policy.UpstreamTimeout != 0 {
-			timeout = policy.UpstreamTimeout
+	if policy.IsTCP() {
+		rp.Methods(http.MethodConnect).Handler(handler)
+	} else {
+		rp.MatcherFunc(matchPath(policy)).Handler(handler)
+	}
+
+	// Optional: render the route's error and sign in pages with custom
+	// templates
+	if templatesDirs := nonEmpty(p.templatesDir, policy.TemplatesDir); len(templatesDirs) != 0 {
+		templates, err := p.customTemplates.Load(templatesDirs...)
+		if err != nil {
+			return nil, fmt.Errorf("proxy: invalid templates for %s: %w", policy, err)
 		}
-		timeoutMsg := fmt.Sprintf("%s timed out in %s", policy.Destination.Host, timeout)
-		rp.Use(middleware.TimeoutHandlerFunc(timeout, timeoutMsg))
+		rp.Use(httputil.SetTemplates(templates))
+	}
+
+	// Optional: reject request bodies larger than the route's, or the
+	// default, limit
+	maxRequestBodyBytes := p.defaultMaxRequestBodyBytes
+	if policy.MaxRequestBodyBytes != 0 {
+		maxRequestBodyBytes = policy.MaxRequestBodyBytes
+	}
+	if maxRequestBodyBytes > 0 {
+		rp.Use(LimitRequestBody(maxRequestBodyBytes))
+	}
+
+	// Optional: compress responses for clients that accept it
+	if policy.Compression.Enabled && !policy.IsTCP() {
+		rp.Use(Compress(policy.Compression))
+	}
+
+	// Optional: time out requests to the upstream. Routes with destination
+	// groups time out each group's requests instead.
+	if len(policy.DestinationGroups) == 0 && hasUpstreamTimeout(policy) {
+		rp.Use(upstreamTimeout(policy, timeout))
 	}
 
 	// Optional: a cors preflight check, skip access control middleware
 	if policy.CORSAllowPreflight {
 		log.Warn().Str("route", policy.String()).Msg("proxy: cors preflight enabled")
-		rp.Use(middleware.CorsBypass(proxy))
+		rp.Use(middleware.CorsBypass(handler))
 	}
 
 	// Optional: if a public route, skip access control middleware
 	if policy.AllowPublicUnauthenticatedAccess {
 		log.Warn().Str("route", policy.String()).Msg("proxy: all access control disabled")
+		if !policy.IsStatic() {
+			rp.Use(longLived(policy, superviseStreams(policy, nil)))
+		}
 		return r, nil
 	}
 
 	// 4. Retrieve the user session and add it to the request context
 	rp.Use(sessions.RetrieveSession(p.sessionLoaders...))
+	// Optional: retrieve the user's identity provider tokens, before their
+	// cookie is stripped too
+	if policy.ForwardIDPToken != "" {
+		rp.Use(p.LoadIDPTokens)
+	}
 	// 5. Strip the user session cookie from the downstream request
 	rp.Use(middleware.StripCookie(p.cookieOptions.Name))
 	// 6. AuthN - Verify the user is authenticated. Set email, group, & id headers
 	rp.Use(p.AuthenticateSession)
 	// 7. AuthZ - Verify the user is authorized for route
 	rp.Use(p.AuthorizeSession)
-	// Optional: Add a signed JWT attesting to the user's id, email, and group
-	if len(p.signingKey) != 0 {
-		signer, err := jws.NewES256Signer(p.signingKey, policy.Destination.Host)
+	// Optional: close long-lived connections once idle, open too long, or no
+	// longer authorized
+	if !policy.IsStatic() {
+		rp.Use(longLived(policy, superviseStreams(policy, p.reauthorize)))
+	}
+	// 8. Set the headers sent upstream for the user
+	headers, err := p.routeHeaders(policy)
+	if err != nil {
+		return nil, err
+	}
+	rp.Use(headers...)
+	return r, nil
+}
+
+// routeHeaders returns the middleware that sets the headers a route's
+// upstream receives for an authorized user. It is shared by the reverse
+// proxy and the forward-auth verify endpoint.
+func (p *Proxy) routeHeaders(policy *config.Policy) ([]mux.MiddlewareFunc, error) {
+	var headers []mux.MiddlewareFunc
+	// Optional: send the user's identity provider token upstream
+	if policy.ForwardIDPToken != "" {
+		headers = append(headers, p.ForwardIDPToken(policy.ForwardIDPToken))
+	}
+	// Optional: act as the user on a Kubernetes API server, using a service
+	// account token
+	if policy.KubernetesServiceAccountToken != "" {
+		headers = append(headers, KubernetesImpersonation(policy.KubernetesServiceAccountToken))
+	}
+	// Optional: Add a signed JWT attesting to the user's id, email, and group.
+	// Routes with destination groups sign it for each group's destination
+	// instead.
+	if len(p.signingKey) != 0 && !policy.IsStatic() && len(policy.DestinationGroups) == 0 {
+		signer, err := jws.NewES256Signer(p.signingKey, upstreamURL(policy).Host)
 		if err != nil {
 			return nil, err
 		}
-		rp.Use(p.SignRequest(signer))
+		headers = append(headers, p.SignRequest(signer))
 	}
 	// Optional: if additional headers are to be set for this url
 	if len(policy.SetRequestHeaders) != 0 {
 		log.Warn().Interface("headers", policy.SetRequestHeaders).Msg("proxy: set request headers")
-		rp.Use(SetResponseHeaders(policy.SetRequestHeaders))
+		headers = append(headers, SetRequestHeaders(policy.SetRequestHeaders))
 	}
-	return r, nil
+	return headers, nil
+}
+
+// signForDestination adds a signed JWT, for one of a route's destination
+// groups, gp, to requests passed to next. Public routes send none.
+func (p *Proxy) signForDestination(gp *config.Policy, next http.Handler) (http.Handler, error) {
+	if len(p.signingKey) == 0 || gp.AllowPublicUnauthenticatedAccess {
+		return next, nil
+	}
+	signer, err := jws.NewES256Signer(p.signingKey, upstreamURL(gp).Host)
+	if err != nil {
+		return nil, err
+	}
+	return p.SignRequest(signer)(next), nil
+}
+
+// hasUpstreamTimeout returns true if a route's requests are timed out.
+// Websockets, and TCP tunnels, cannot use the non-hijackable
+// timeout-handler, and static routes have no upstream to time out.
+func hasUpstreamTimeout(policy *config.Policy) bool {
+	return !policy.AllowWebsockets && !policy.IsTCP() && !policy.IsStatic()
+}
+
+// upstreamTimeout returns the middleware timing out requests to a route's
+// upstream, other than gRPC calls, which set their own deadlines.
+func upstreamTimeout(policy *config.Policy, timeout time.Duration) mux.MiddlewareFunc {
+	timeoutMsg := fmt.Sprintf("%s timed out in %s", upstreamURL(policy).Host, timeout)
+	return unlessGRPC(middleware.TimeoutHandlerFunc(timeout, timeoutMsg))
+}
+
+// reverseProxy creates a reverse proxy to a route's destination.
+func (p *Proxy) reverseProxy(policy *config.Policy) http.Handler {
+	proxy := httputil.NewReverseProxy(upstreamURL(policy))
+	// 2. Override any custom transport settings (e.g. TLS settings, etc)
+	proxy.Transport = p.roundTripperFromPolicy(policy)
+	proxy.ErrorHandler = errorHandler
+	var modifiers []func(*http.Response) error
+	// Optional: rewrite the upstream request's path, and any redirects or
+	// cookies in the upstream's response
+	if pr := newPathRewriter(policy); pr != nil {
+		proxy.Director = pr.Director(proxy.Director)
+		modifiers = append(modifiers, pr.ModifyResponse)
+	}
+	// Optional: send the original, or a custom, host header upstream instead
+	// of the destination's hostname
+	if policy.PreserveHostHeader || policy.HostRewrite != "" {
+		proxy.Director = rewriteHost(proxy.Director, policy.HostRewrite)
+	}
+	// Optional: strip headers from the request before it is sent upstream
+	if len(policy.RemoveRequestHeaders) != 0 {
+		proxy.Director = removeRequestHeaders(proxy.Director, policy.RemoveRequestHeaders)
+	}
+	// Optional: set, or strip, headers on the upstream's response
+	if len(policy.SetResponseHeaders) != 0 || len(policy.RemoveResponseHeaders) != 0 {
+		modifiers = append(modifiers, modifyResponseHeaders(policy.SetResponseHeaders, policy.RemoveResponseHeaders))
+	}
+	if len(modifiers) != 0 {
+		proxy.ModifyResponse = modifyResponse(modifiers...)
+	}
+	return proxy
+}
+
+// upstreamURL returns the url requests are proxied to. It differs from the
+// policy's destination if the transport needs plain http requests, as with
+// h2c upstreams, or if the destination has no host, as with unix sockets.
+// Unix socket upstreams receive the route's own host.
+func upstreamURL(policy *config.Policy) *url.URL {
+	switch {
+	case policy.IsUnix():
+		return &url.URL{Scheme: "http", Host: policy.Source.Host}
+	case policy.IsH2C():
+		u := *policy.Destination
+		u.Scheme = "http"
+		return &u
+	}
+	return policy.Destination
 }
 
 // roundTripperFromPolicy adjusts the std library's `DefaultTransport RoundTripper`
 // for a given route. A route's `RoundTripper` establishes network connections
 // as needed and caches them for reuse by subsequent calls.
 func (p *Proxy) roundTripperFromPolicy(policy *config.Policy) http.RoundTripper {
-	transport := http.DefaultTransport.(*http.Transport).Clone()
+	upstream := upstreamURL(policy)
 	c := tripper.NewChain()
-	c = c.Append(metrics.HTTPMetricsRoundTripper("proxy", policy.Destination.Host))
+	// Optional: copy requests to a shadow upstream, including those that
+	// are served from the cache
+	if policy.Mirror.Enabled() {
+		var setHeaders []string
+		for key := range policy.SetRequestHeaders {
+			setHeaders = append(setHeaders, key)
+		}
+		c = c.Append(mirrorRoundTripper(policy.Mirror, setHeaders))
+	}
+	// Optional: serve fresh responses from an in-memory cache, before they
+	// are counted as upstream requests
+	if policy.Cache.Enabled() {
+		c = c.Append(cacheRoundTripper(upstream.Host, policy.Cache))
+	}
+	c = c.Append(metrics.HTTPMetricsRoundTripper("proxy", upstream.Host))
+	// Optional: retry failed idempotent requests
+	if policy.RetryPolicy.Enabled() {
+		c = c.Append(retryRoundTripper(policy.RetryPolicy))
+	}
+	// Optional: fail fast while the upstream is unhealthy
+	if policy.CircuitBreaker.Enabled() {
+		c = c.Append(circuitBreakerRoundTripper(p.circuitBreakers.get(upstream.Host, policy.CircuitBreaker)))
+	}
+
+	// Optional: speak cleartext HTTP/2 to gRPC, and other h2c, upstreams
+	if policy.IsH2C() {
+		return c.Then(h2cTransport())
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// Optional: dial a unix domain socket rather than the upstream's host
+	if policy.IsUnix() {
+		socket := policy.Destination.Path
+		dialer := net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		transport.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, "unix", socket)
+		}
+	}
 
 	var tlsClientConfig tls.Config
 	var isCustomClientConfig bool
@@ -306,3 +638,14 @@ func (p *Proxy) roundTripperFromPolicy(policy *config.Policy) http.RoundTripper
 func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	p.Handler.ServeHTTP(w, r)
 }
+
+// nonEmpty returns the strings that are not empty.
+func nonEmpty(ss ...string) []string {
+	var out []string
+	for _, s := range ss {
+		if s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
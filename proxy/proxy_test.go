@@ -1,9 +1,13 @@
 package proxy // import "github.com/pomerium/pomerium/proxy"
 
 import (
+	"io/ioutil"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -176,6 +180,59 @@ func Test_UpdateOptions(t *testing.T) {
 	fwdAuth.ForwardAuthURL = &url.URL{Scheme: "https", Host: "corp.example.example"}
 	reqHeaders := testOptions(t)
 	reqHeaders.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", SetRequestHeaders: map[string]string{"x": "y"}}}
+	pathRewrite := testOptions(t)
+	pathRewrite.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", Prefix: "/grafana", PrefixRewrite: "/"}}
+	modifyHeaders := testOptions(t)
+	modifyHeaders.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", RemoveRequestHeaders: []string{"X-Secret"}, SetResponseHeaders: map[string]string{"X-Frame-Options": "DENY"}, RemoveResponseHeaders: []string{"Server"}}}
+	preserveHost := testOptions(t)
+	preserveHost.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", PreserveHostHeader: true}}
+	retryPolicy := testOptions(t)
+	retryPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", RetryPolicy: config.RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{503}}}}
+	circuitBreaker := testOptions(t)
+	circuitBreaker.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", CircuitBreaker: config.CircuitBreaker{ErrorRateThreshold: 0.5, MaxConcurrentRequests: 100}}}
+	maxRequestBodyBytes := testOptions(t)
+	maxRequestBodyBytes.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", MaxRequestBodyBytes: 1 << 20}}
+	tcpPolicy := testOptions(t)
+	tcpPolicy.Policies = []config.Policy{{To: "tcp://foo.example:6379", From: "tcp+https://bar.example:6379"}}
+	redirectPolicy := testOptions(t)
+	redirectPolicy.Policies = []config.Policy{{From: "http://bar.example", Redirect: &config.PolicyRedirect{To: "http://foo.example", PreservePath: true}}}
+	destinationGroups := testOptions(t)
+	destinationGroups.Policies = []config.Policy{{From: "http://bar.example", DestinationGroups: []config.DestinationGroup{{Name: "stable", To: "http://foo.example", Weight: 95}, {Name: "canary", To: "http://foo-next.example", Weight: 5}}}}
+	mirrorPolicy := testOptions(t)
+	mirrorPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", Mirror: config.Mirror{To: "http://shadow.example"}}}
+	cachePolicy := testOptions(t)
+	cachePolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", Cache: config.Cache{MaxSizeBytes: 1 << 20}}}
+	grpcPolicy := testOptions(t)
+	grpcPolicy.Policies = []config.Policy{{To: "grpc://foo.example:50051", From: "http://bar.example"}}
+	streamingPolicy := testOptions(t)
+	streamingPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", AllowWebsockets: true, IdleTimeout: time.Minute, MaxConnectionDuration: time.Hour}}
+	unixPolicy := testOptions(t)
+	unixPolicy.Policies = []config.Policy{{To: "unix:///var/run/foo.sock", From: "http://bar.example"}}
+	badUnixPolicy := testOptions(t)
+	badUnixPolicy.Policies = []config.Policy{{To: "unix://foo.sock", From: "http://bar.example"}}
+	forwardTokenPolicy := testOptions(t)
+	forwardTokenPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", ForwardIDPToken: "id_token"}}
+	badForwardTokenPolicy := testOptions(t)
+	badForwardTokenPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", ForwardIDPToken: "refresh_token"}}
+	kubernetesPolicy := testOptions(t)
+	kubernetesPolicy.Policies = []config.Policy{{To: "https://kubernetes.default.svc", From: "http://bar.example", KubernetesServiceAccountToken: "token"}}
+	templatesDir, err := ioutil.TempDir("", "pomerium-templates")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(templatesDir)
+	if err := ioutil.WriteFile(filepath.Join(templatesDir, "error.html"), []byte("{{.Status}}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	templatesPolicy := testOptions(t)
+	templatesPolicy.TemplatesDir = templatesDir
+	templatesPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", TemplatesDir: templatesDir}}
+	badTemplatesPolicy := testOptions(t)
+	badTemplatesPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", TemplatesDir: filepath.Join(templatesDir, "missing")}}
+	compressionPolicy := testOptions(t)
+	compressionPolicy.Policies = []config.Policy{{To: "http://foo.example", From: "http://bar.example", Compression: config.Compression{Enabled: true}}}
+	responsePolicy := testOptions(t)
+	responsePolicy.Policies = []config.Policy{{From: "http://bar.example", Response: &config.PolicyResponse{Status: 503, Body: "down for maintenance"}}}
 	tests := []struct {
 		name            string
 		originalOptions config.Options
@@ -203,6 +260,28 @@ func Test_UpdateOptions(t *testing.T) {
 		{"disable auth", good, disableAuth, "", "https://corp.example.example", false, true},
 		{"enable forward auth", good, fwdAuth, "", "https://corp.example.example", false, true},
 		{"set request headers", good, reqHeaders, "", "https://corp.example.example", false, true},
+		{"path rewrite", good, pathRewrite, "", "https://corp.example.example", false, true},
+		{"modify headers", good, modifyHeaders, "", "https://corp.example.example", false, true},
+		{"preserve host header", good, preserveHost, "", "https://corp.example.example", false, true},
+		{"retry policy", good, retryPolicy, "", "https://corp.example.example", false, true},
+		{"circuit breaker", good, circuitBreaker, "", "https://corp.example.example", false, true},
+		{"destination groups", good, destinationGroups, "", "https://corp.example.example", false, true},
+		{"mirror", good, mirrorPolicy, "", "https://corp.example.example", false, true},
+		{"max request body bytes", good, maxRequestBodyBytes, "", "https://corp.example.example", false, true},
+		{"tcp", good, tcpPolicy, "", "https://corp.example.example", false, true},
+		{"redirect", good, redirectPolicy, "", "https://corp.example.example", false, true},
+		{"static response", good, responsePolicy, "", "https://corp.example.example", false, true},
+		{"cache", good, cachePolicy, "", "https://corp.example.example", false, true},
+		{"compression", good, compressionPolicy, "", "https://corp.example.example", false, true},
+		{"grpc", good, grpcPolicy, "", "https://corp.example.example", false, true},
+		{"streaming", good, streamingPolicy, "", "https://corp.example.example", false, true},
+		{"unix socket", good, unixPolicy, "", "https://corp.example.example", false, true},
+		{"bad unix socket", good, badUnixPolicy, "", "https://corp.example.example", true, true},
+		{"forward idp token", good, forwardTokenPolicy, "", "https://corp.example.example", false, true},
+		{"bad forward idp token", good, badForwardTokenPolicy, "", "https://corp.example.example", true, true},
+		{"kubernetes", good, kubernetesPolicy, "", "https://corp.example.example", false, true},
+		{"templates", good, templatesPolicy, "", "https://corp.example.example", false, true},
+		{"bad templates", good, badTemplatesPolicy, "", "https://corp.example.example", true, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -235,3 +314,83 @@ func Test_UpdateOptions(t *testing.T) {
 	var p *Proxy
 	p.UpdateOptions(config.Options{})
 }
+
+func TestReverseProxy_Unix(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "pomerium")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "app.sock")
+	ln, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(r.Host + r.URL.Path))
+	}))
+	upstream.Listener = ln
+	upstream.Start()
+	defer upstream.Close()
+
+	policy := config.Policy{From: "https://httpbin.corp.example", To: "unix://" + socket}
+	if err := policy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	p := &Proxy{}
+	r := httptest.NewRequest(http.MethodGet, "https://httpbin.corp.example/status/200", nil)
+	w := httptest.NewRecorder()
+	p.reverseProxy(&policy).ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusOK, w.Body)
+	}
+	// the upstream sees the route's host, and the request's path
+	if got, want := w.Body.String(), "httpbin.corp.example/status/200"; got != want {
+		t.Errorf("upstream saw %q, want %q", got, want)
+	}
+}
+
+func TestProxy_routes(t *testing.T) {
+	t.Parallel()
+	upstream := func(name string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(name))
+		}))
+	}
+	root, grafana := upstream("root"), upstream("grafana")
+	defer root.Close()
+	defer grafana.Close()
+
+	opts := testOptions(t)
+	// the host wide route is listed first, but does not shadow the prefix
+	opts.Policies = []config.Policy{
+		{From: "https://apps.example", To: root.URL, AllowPublicUnauthenticatedAccess: true},
+		{From: "https://apps.example", To: grafana.URL, Prefix: "/grafana", AllowPublicUnauthenticatedAccess: true},
+	}
+	p, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path      string
+		want      string
+		wantRoute string
+	}{
+		{"/", "root", "apps.example"},
+		{"/grafana", "grafana", "apps.example/grafana"},
+		{"/grafana/login", "grafana", "apps.example/grafana"},
+		{"/grafanafoo", "root", "apps.example"},
+	}
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		p.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "https://apps.example"+tt.path, nil))
+		if got := w.Body.String(); got != tt.want {
+			t.Errorf("%s routed to %q, want %q", tt.path, got, tt.want)
+		}
+		// each route is authorized separately
+		if got := p.routeID("apps.example", tt.path); got != tt.wantRoute {
+			t.Errorf("routeID(%q) = %q, want %q", tt.path, got, tt.wantRoute)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/telemetry/metrics"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+// retryRoundTripper returns a tripper that retries failed, idempotent,
+// requests according to a route's retry policy.
+func retryRoundTripper(policy config.RetryPolicy) tripper.Constructor {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return &retryTransport{next: next, policy: policy}
+	}
+}
+
+type retryTransport struct {
+	next   http.RoundTripper
+	policy config.RetryPolicy
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !isRetriableRequest(req) {
+		return t.next.RoundTrip(req)
+	}
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		res, retry, err := t.roundTrip(req, attempt)
+		if !retry {
+			return res, err
+		}
+		if attempt >= t.policy.MaxAttempts {
+			metrics.RecordHTTPClientRetriesExhausted(ctx)
+			return res, err
+		}
+		if res != nil {
+			// drain the body so that the underlying connection can be reused
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+		}
+		if err := sleepContext(ctx, t.backoff(attempt)); err != nil {
+			return nil, err
+		}
+		metrics.RecordHTTPClientRetry(ctx)
+	}
+}
+
+// roundTrip makes a single attempt, and reports whether it should be retried.
+func (t *retryTransport) roundTrip(req *http.Request, attempt int) (*http.Response, bool, error) {
+	ctx, cancel := req.Context(), context.CancelFunc(func() {})
+	if t.policy.PerTryTimeout != 0 {
+		ctx, cancel = context.WithTimeout(ctx, t.policy.PerTryTimeout)
+	}
+	r := req.WithContext(ctx)
+	if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, false, err
+		}
+		r.Body = body
+	}
+
+	res, err := t.next.RoundTrip(r)
+	if err != nil {
+		cancel()
+		// a per-try timeout is retried, but not the request itself timing out
+		perTryTimeout := ctx.Err() == context.DeadlineExceeded && req.Context().Err() == nil
+		return nil, perTryTimeout || isConnectError(err), err
+	}
+	// the per-try timeout must last until the response body has been read
+	res.Body = &cancelReadCloser{ReadCloser: res.Body, cancel: cancel}
+	for _, code := range t.policy.RetriableStatusCodes {
+		if res.StatusCode == code {
+			return res, true, nil
+		}
+	}
+	return res, false, nil
+}
+
+// backoff returns a random duration, between zero and an exponentially
+// increasing ceiling, to wait before the next attempt.
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	ceiling := t.policy.BackoffBaseInterval << uint(attempt-1)
+	if ceiling > t.policy.BackoffMaxInterval || ceiling <= 0 {
+		ceiling = t.policy.BackoffMaxInterval
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1))
+}
+
+// isRetriableRequest reports whether a request is idempotent, and can be
+// safely replayed.
+//
+// https://tools.ietf.org/html/rfc7231#section-4.2.2
+func isRetriableRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+	default:
+		return false
+	}
+	// upgraded connections (e.g. websockets) are hijacked, not retried
+	if req.Header.Get("Upgrade") != "" {
+		return false
+	}
+	return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+}
+
+// isConnectError reports whether an error occurred while trying to
+// establish a connection to the upstream.
+func isConnectError(err error) bool {
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// sleepContext pauses for the given duration, or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// cancelReadCloser cancels a context once its ReadCloser has been closed.
+type cancelReadCloser struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,112 @@
+package proxy
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/tripper"
+)
+
+func TestRetryRoundTripper(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		policy       config.RetryPolicy
+		failures     int32
+		failStatus   int
+		delay        time.Duration
+		wantStatus   int
+		wantAttempts int32
+		wantErr      bool
+	}{
+		{"no failures", http.MethodGet, "", config.RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{503}}, 0, 0, 0, http.StatusOK, 1, false},
+		{"recovers", http.MethodGet, "", config.RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{503}}, 2, http.StatusServiceUnavailable, 0, http.StatusOK, 3, false},
+		{"exhausted", http.MethodGet, "", config.RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{503}}, 5, http.StatusServiceUnavailable, 0, http.StatusServiceUnavailable, 3, false},
+		{"status not retriable", http.MethodGet, "", config.RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{503}}, 1, http.StatusBadGateway, 0, http.StatusBadGateway, 1, false},
+		{"not idempotent", http.MethodPost, "", config.RetryPolicy{MaxAttempts: 3, RetriableStatusCodes: []int{503}}, 1, http.StatusServiceUnavailable, 0, http.StatusServiceUnavailable, 1, false},
+		{"replays body", http.MethodPut, "hello", config.RetryPolicy{MaxAttempts: 2, RetriableStatusCodes: []int{503}}, 1, http.StatusServiceUnavailable, 0, http.StatusOK, 2, false},
+		{"per try timeout", http.MethodGet, "", config.RetryPolicy{MaxAttempts: 2, PerTryTimeout: 50 * time.Millisecond}, 1, 0, 500 * time.Millisecond, http.StatusOK, 2, false},
+		{"per try timeout exhausted", http.MethodGet, "", config.RetryPolicy{MaxAttempts: 2, PerTryTimeout: 50 * time.Millisecond}, 2, 0, 500 * time.Millisecond, 0, 2, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				body, _ := ioutil.ReadAll(r.Body)
+				if string(body) != tt.body {
+					t.Errorf("attempt %d got body %q, want %q", attempt, body, tt.body)
+				}
+				if attempt <= tt.failures {
+					if tt.delay != 0 {
+						select {
+						case <-time.After(tt.delay):
+						case <-r.Context().Done():
+						}
+						return
+					}
+					w.WriteHeader(tt.failStatus)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer backend.Close()
+
+			if err := tt.policy.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			client := http.Client{Transport: retryRoundTripper(tt.policy)(http.DefaultTransport)}
+			var body io.Reader
+			if tt.body != "" {
+				body = strings.NewReader(tt.body)
+			}
+			req, _ := http.NewRequest(tt.method, backend.URL, body)
+			res, err := client.Do(req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RoundTrip() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil {
+				res.Body.Close()
+				if res.StatusCode != tt.wantStatus {
+					t.Errorf("RoundTrip() status = %d, want %d", res.StatusCode, tt.wantStatus)
+				}
+			}
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("RoundTrip() attempts = %d, want %d", got, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestRetryRoundTripper_ConnectError(t *testing.T) {
+	t.Parallel()
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	backend.Close()
+
+	policy := config.RetryPolicy{MaxAttempts: 3}
+	if err := policy.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	var attempts int32
+	counter := tripper.RoundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		atomic.AddInt32(&attempts, 1)
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	client := http.Client{Transport: retryRoundTripper(policy)(counter)}
+	_, err := client.Get(backend.URL)
+	if err == nil || !strings.Contains(err.Error(), "connect") {
+		t.Errorf("RoundTrip() expected connect error, got %v", err)
+	}
+	if got := atomic.LoadInt32(&attempts); got != 3 {
+		t.Errorf("RoundTrip() attempts = %d, want %d", got, 3)
+	}
+}
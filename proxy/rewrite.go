@@ -0,0 +1,153 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/pomerium/pomerium/config"
+)
+
+// pathRewriter rewrites the path of requests sent upstream according to a
+// route's prefix or regex rewrite policy. Redirects and cookies set by the
+// upstream are mapped back to their downstream equivalents.
+type pathRewriter struct {
+	source *url.URL
+	// upstreamHost is the host header sent upstream, and so the host the
+	// upstream will use in any absolute redirects
+	upstreamHost string
+
+	// downstream is the route's matched prefix, which is replaced by rewrite
+	// before the request is sent. upstream is the resulting prefix seen by
+	// the upstream once the destination's path has been joined.
+	downstream string
+	rewrite    string
+	upstream   string
+
+	re           *regexp.Regexp
+	substitution string
+}
+
+// newPathRewriter returns a pathRewriter for a given policy, or nil if the
+// policy does not rewrite paths.
+func newPathRewriter(policy *config.Policy) *pathRewriter {
+	if policy.PrefixRewrite == "" && policy.RegexRewrite.Regexp == nil {
+		return nil
+	}
+	pr := &pathRewriter{
+		source:       policy.Source,
+		upstreamHost: upstreamURL(policy).Host,
+		re:           policy.RegexRewrite.Regexp,
+		substitution: policy.RegexRewrite.Substitution,
+	}
+	switch {
+	case policy.PreserveHostHeader:
+		pr.upstreamHost = policy.Source.Host
+	case policy.HostRewrite != "":
+		pr.upstreamHost = policy.HostRewrite
+	}
+	if policy.PrefixRewrite != "" {
+		pr.downstream = "/"
+		if policy.Prefix != "" {
+			pr.downstream = policy.Prefix
+		}
+		pr.rewrite = policy.PrefixRewrite
+		pr.upstream = joinPath(upstreamURL(policy).Path, policy.PrefixRewrite)
+	}
+	return pr
+}
+
+// Director wraps a reverse proxy director, rewriting the request's path
+// before it is passed along.
+func (pr *pathRewriter) Director(director func(*http.Request)) func(*http.Request) {
+	return func(req *http.Request) {
+		req.URL.Path = pr.rewritePath(req.URL.Path)
+		if req.URL.RawPath != "" {
+			req.URL.RawPath = pr.rewritePath(req.URL.RawPath)
+		}
+		director(req)
+	}
+}
+
+func (pr *pathRewriter) rewritePath(p string) string {
+	if pr.re != nil {
+		return pr.re.ReplaceAllString(p, pr.substitution)
+	}
+	return swapPrefix(p, pr.downstream, pr.rewrite)
+}
+
+// ModifyResponse maps any `Location` header, and any `Set-Cookie` paths,
+// returned by the upstream back to the route's source.
+func (pr *pathRewriter) ModifyResponse(res *http.Response) error {
+	if loc := res.Header.Get("Location"); loc != "" {
+		res.Header.Set("Location", pr.rewriteLocation(loc))
+	}
+	if cookies := res.Header["Set-Cookie"]; len(cookies) != 0 && pr.re == nil {
+		for i := range cookies {
+			cookies[i] = pr.rewriteCookiePath(cookies[i])
+		}
+	}
+	return nil
+}
+
+func (pr *pathRewriter) rewriteLocation(loc string) string {
+	u, err := url.Parse(loc)
+	if err != nil {
+		return loc
+	}
+	if u.Host != "" {
+		if u.Host != pr.upstreamHost {
+			return loc
+		}
+		u.Scheme = pr.source.Scheme
+		u.Host = pr.source.Host
+	}
+	// paths rewritten by a regular expression can't be reliably reversed
+	if pr.re == nil && strings.HasPrefix(u.Path, "/") {
+		u.Path = swapPrefix(u.Path, pr.upstream, pr.downstream)
+		u.RawPath = ""
+	}
+	return u.String()
+}
+
+// rewriteCookiePath rewrites the path attribute of a raw `Set-Cookie` header
+// value, leaving every other attribute untouched.
+func (pr *pathRewriter) rewriteCookiePath(cookie string) string {
+	attrs := strings.Split(cookie, ";")
+	// the first attribute is always the cookie's name and value
+	for i := 1; i < len(attrs); i++ {
+		kv := strings.SplitN(strings.TrimSpace(attrs[i]), "=", 2)
+		if len(kv) != 2 || !strings.EqualFold(kv[0], "path") {
+			continue
+		}
+		attrs[i] = " " + kv[0] + "=" + swapPrefix(kv[1], pr.upstream, pr.downstream)
+	}
+	return strings.Join(attrs, ";")
+}
+
+// swapPrefix replaces the prefix `from` in `p` with `to`. If `p` does not
+// begin with `from`, it is returned unchanged.
+func swapPrefix(p, from, to string) string {
+	if !strings.HasPrefix(p, from) {
+		return p
+	}
+	rest := strings.TrimPrefix(p, from)
+	if rest == "" {
+		return to
+	}
+	return joinPath(to, rest)
+}
+
+// joinPath joins two paths with exactly one slash between them.
+func joinPath(a, b string) string {
+	aslash := strings.HasSuffix(a, "/")
+	bslash := strings.HasPrefix(b, "/")
+	switch {
+	case aslash && bslash:
+		return a + b[1:]
+	case !aslash && !bslash:
+		return a + "/" + b
+	}
+	return a + b
+}
@@ -0,0 +1,101 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/httputil"
+)
+
+func TestPathRewriter_Director(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		policy   config.Policy
+		path     string
+		wantPath string
+	}{
+		{"no rewrite", config.Policy{From: "https://from.example", To: "https://to.example"}, "/grafana/api", "/grafana/api"},
+		{"prefix to root", config.Policy{From: "https://from.example", To: "https://to.example", Prefix: "/grafana", PrefixRewrite: "/"}, "/grafana/api", "/api"},
+		{"prefix with slash to root", config.Policy{From: "https://from.example", To: "https://to.example", Prefix: "/grafana/", PrefixRewrite: "/"}, "/grafana/api", "/api"},
+		{"exact prefix", config.Policy{From: "https://from.example", To: "https://to.example", Prefix: "/grafana", PrefixRewrite: "/"}, "/grafana", "/"},
+		{"root to prefix", config.Policy{From: "https://from.example", To: "https://to.example", PrefixRewrite: "/app"}, "/api", "/app/api"},
+		{"prefix with destination path", config.Policy{From: "https://from.example", To: "https://to.example/base", Prefix: "/grafana", PrefixRewrite: "/v2"}, "/grafana/api", "/base/v2/api"},
+		{"regex", config.Policy{From: "https://from.example", To: "https://to.example", RegexRewrite: config.RegexRewrite{Pattern: "^/grafana", Substitution: ""}}, "/grafana/api", "/api"},
+		{"regex with expansion", config.Policy{From: "https://from.example", To: "https://to.example", RegexRewrite: config.RegexRewrite{Pattern: "^/service/([^/]+)(/.*)$", Substitution: "${2}/instance/${1}"}}, "/service/foo/v1/api", "/v1/api/instance/foo"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			proxy := httputil.NewReverseProxy(tt.policy.Destination)
+			if pr := newPathRewriter(&tt.policy); pr != nil {
+				proxy.Director = pr.Director(proxy.Director)
+			}
+			r := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			proxy.Director(r)
+			if diff := cmp.Diff(tt.wantPath, r.URL.Path); diff != "" {
+				t.Errorf("Director() path:\n %s", diff)
+			}
+		})
+	}
+}
+
+func TestPathRewriter_ModifyResponse(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		policy       config.Policy
+		location     string
+		cookies      []string
+		wantLocation string
+		wantCookies  []string
+	}{
+		{"relative location", config.Policy{From: "https://from.example", To: "https://to.example", Prefix: "/grafana", PrefixRewrite: "/"}, "/login", nil, "/grafana/login", nil},
+		{"absolute location", config.Policy{From: "https://from.example", To: "http://to.example", Prefix: "/grafana", PrefixRewrite: "/"}, "http://to.example/login?next=%2F", nil, "https://from.example/grafana/login?next=%2F", nil},
+		{"host rewrite location", config.Policy{From: "https://from.example", To: "http://to.example", HostRewrite: "custom.example", Prefix: "/grafana", PrefixRewrite: "/"}, "http://custom.example/login", nil, "https://from.example/grafana/login", nil},
+		{"other host location", config.Policy{From: "https://from.example", To: "http://to.example", Prefix: "/grafana", PrefixRewrite: "/"}, "https://idp.example/login", nil, "https://idp.example/login", nil},
+		{"unmatched location", config.Policy{From: "https://from.example", To: "https://to.example", Prefix: "/grafana", PrefixRewrite: "/app"}, "/other", nil, "/other", nil},
+		{"destination path location", config.Policy{From: "https://from.example", To: "https://to.example/base", Prefix: "/grafana", PrefixRewrite: "/"}, "/base/login", nil, "/grafana/login", nil},
+		{"regex location host only", config.Policy{From: "https://from.example", To: "http://to.example", RegexRewrite: config.RegexRewrite{Pattern: "^/a", Substitution: "/b"}}, "http://to.example/b", nil, "https://from.example/b", nil},
+		{"cookies",
+			config.Policy{From: "https://from.example", To: "https://to.example", Prefix: "/grafana", PrefixRewrite: "/"},
+			"",
+			[]string{"a=b; Path=/; HttpOnly", "path=c; path=/api; Secure", "d=e"},
+			"",
+			[]string{"a=b; Path=/grafana; HttpOnly", "path=c; path=/grafana/api; Secure", "d=e"}},
+		{"regex cookies unchanged",
+			config.Policy{From: "https://from.example", To: "https://to.example", RegexRewrite: config.RegexRewrite{Pattern: "^/a", Substitution: "/b"}},
+			"",
+			[]string{"a=b; Path=/b"},
+			"",
+			[]string{"a=b; Path=/b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.policy.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			res := &http.Response{Header: make(http.Header)}
+			if tt.location != "" {
+				res.Header.Set("Location", tt.location)
+			}
+			for _, c := range tt.cookies {
+				res.Header.Add("Set-Cookie", c)
+			}
+			if err := newPathRewriter(&tt.policy).ModifyResponse(res); err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.wantLocation, res.Header.Get("Location")); diff != "" {
+				t.Errorf("ModifyResponse() location:\n %s", diff)
+			}
+			if diff := cmp.Diff(tt.wantCookies, res.Header["Set-Cookie"]); diff != "" {
+				t.Errorf("ModifyResponse() cookies:\n %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/internal/version"
+)
+
+const (
+	// sessionsURL is the path to authenticate's endpoint listing a user's
+	// sessions
+	sessionsURL = "/api/v1/sessions"
+	// revokeSessionURL is the path to authenticate's session revocation
+	// endpoint
+	revokeSessionURL = "/api/v1/sessions/revoke"
+	// revokedSessionsURL is the path to authenticate's endpoint listing
+	// revoked sessions
+	revokedSessionsURL = "/api/v1/sessions/revoked"
+)
+
+// revokedSessionsTTL is how often the list of revoked sessions is fetched
+// from the authenticate service.
+const revokedSessionsTTL = 10 * time.Second
+
+// sessionInventory is a client of the authenticate service's session
+// inventory. Revoked sessions are checked against a list of revoked session
+// ids, fetched periodically in the background, so that each request is not a
+// round trip to the authenticate service.
+type sessionInventory struct {
+	sharedKey string
+
+	mu              sync.Mutex
+	authenticateURL *url.URL
+	revoked         map[string]struct{}
+}
+
+func newSessionInventory(sharedKey string, authenticateURL *url.URL) *sessionInventory {
+	return &sessionInventory{
+		sharedKey:       sharedKey,
+		authenticateURL: authenticateURL,
+		revoked:         make(map[string]struct{}),
+	}
+}
+
+// run fetches the list of revoked sessions every revokedSessionsTTL, until
+// ctx is done.
+func (si *sessionInventory) run(ctx context.Context) {
+	ticker := time.NewTicker(revokedSessionsTTL)
+	defer ticker.Stop()
+	for {
+		updateCtx, cancel := context.WithTimeout(ctx, revokedSessionsTTL)
+		si.update(updateCtx)
+		cancel()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// setAuthenticateURL changes the authenticate service the inventory is
+// fetched from, as when the configuration is reloaded.
+func (si *sessionInventory) setAuthenticateURL(u *url.URL) {
+	si.mu.Lock()
+	si.authenticateURL = u
+	si.mu.Unlock()
+}
+
+// IsRevoked reports whether the session has been revoked. Service accounts
+// are not tracked by the authenticate service, and are revoked in the
+// configuration instead.
+func (si *sessionInventory) IsRevoked(_ context.Context, s *sessions.State) bool {
+	if s.ServiceAccount {
+		return false
+	}
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	_, ok := si.revoked[s.ID]
+	return ok
+}
+
+// update fetches the list of revoked sessions. If it cannot be fetched, the
+// last known list is kept.
+func (si *sessionInventory) update(ctx context.Context) {
+	var response struct {
+		Revoked []string `json:"revoked"`
+	}
+	endpoint, params := si.signedURL(revokedSessionsURL, nil)
+	if err := httputil.Client(ctx, http.MethodGet, endpoint, version.UserAgent(), nil, params, &response); err != nil {
+		log.Warn().Err(err).Msg("proxy: could not update revoked sessions")
+		return
+	}
+	revoked := make(map[string]struct{}, len(response.Revoked))
+	for _, id := range response.Revoked {
+		revoked[id] = struct{}{}
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.revoked = revoked
+}
+
+// list returns the user's active sessions.
+func (si *sessionInventory) list(ctx context.Context, user string) ([]sessions.Record, error) {
+	var response struct {
+		Sessions []sessions.Record `json:"sessions"`
+	}
+	endpoint, params := si.signedURL(sessionsURL, url.Values{"user": {user}})
+	if err := httputil.Client(ctx, http.MethodGet, endpoint, version.UserAgent(), nil, params, &response); err != nil {
+		return nil, fmt.Errorf("proxy: could not list sessions: %w", err)
+	}
+	return response.Sessions, nil
+}
+
+// revoke revokes the session with the given id. If user is set, the session
+// must belong to them. The session is rejected by this proxy right away,
+// without waiting for the list of revoked sessions to be updated.
+func (si *sessionInventory) revoke(ctx context.Context, id, user string) error {
+	endpoint, _ := si.signedURL(revokeSessionURL, nil)
+	params := url.Values{"id": {id}}
+	if user != "" {
+		params.Set("user", user)
+	}
+	if err := httputil.Client(ctx, http.MethodPost, endpoint, version.UserAgent(), nil, params, nil); err != nil {
+		return fmt.Errorf("proxy: could not revoke session: %w", err)
+	}
+	si.mu.Lock()
+	si.revoked[id] = struct{}{}
+	si.mu.Unlock()
+	return nil
+}
+
+// signedURL returns the signed url of the given authenticate endpoint and
+// query. The query is also returned on its own, as the client replaces the
+// url's query with the params of GET requests.
+func (si *sessionInventory) signedURL(path string, query url.Values) (string, url.Values) {
+	si.mu.Lock()
+	u := si.authenticateURL.ResolveReference(&url.URL{Path: path, RawQuery: query.Encode()})
+	si.mu.Unlock()
+	signed := urlutil.NewSignedURL(si.sharedKey, u).Sign()
+	return signed.String(), signed.Query()
+}
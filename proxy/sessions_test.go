@@ -0,0 +1,328 @@
+package proxy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/encoding/jws"
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+	"github.com/pomerium/pomerium/proxy/clients"
+)
+
+// newTestInventoryServer returns a fake authenticate service serving the
+// session inventory api from inventory, and a count of revoked session list
+// requests.
+func newTestInventoryServer(t *testing.T, sharedKey string, inventory sessions.Inventory) (*httptest.Server, *int32) {
+	var revokedRequests int32
+	write := func(w http.ResponseWriter, v interface{}) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	r := httputil.NewRouter()
+	// the test server is served over http, not https as signed urls are
+	// usually validated with
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			u := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: r.URL.RawQuery}
+			if err := urlutil.NewSignedURL(sharedKey, &u).Validate(); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	})
+	r.Path(sessionsURL).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		records, _ := inventory.List(r.Context(), r.FormValue("user"))
+		write(w, map[string]interface{}{"sessions": records})
+	})
+	r.Path(revokeSessionURL).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := inventory.Revoke(r.Context(), r.PostFormValue("id"), r.PostFormValue("user")); err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+		write(w, struct{}{})
+	})
+	r.Path(revokedSessionsURL).HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&revokedRequests, 1)
+		ids, _ := inventory.RevokedIDs(r.Context())
+		write(w, map[string]interface{}{"revoked": ids})
+	})
+	return httptest.NewServer(r), &revokedRequests
+}
+
+func newTestInventory(t *testing.T) sessions.Inventory {
+	inventory := sessions.NewMemoryInventory(time.Hour)
+	for _, r := range []sessions.Record{
+		{ID: "a", User: "user", UserAgent: "browser a", CreatedAt: time.Now(), Expiry: time.Now().Add(time.Hour)},
+		{ID: "b", User: "user", UserAgent: "browser b", CreatedAt: time.Now(), Expiry: time.Now().Add(time.Hour)},
+		{ID: "c", User: "other", UserAgent: "browser c", CreatedAt: time.Now(), Expiry: time.Now().Add(time.Hour)},
+	} {
+		if err := inventory.Add(context.Background(), r); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return inventory
+}
+
+func TestSessionInventory(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	sharedKey := testOptions(t).SharedKey
+	inventory := newTestInventory(t)
+	inventory.Revoke(ctx, "a", "")
+	srv, revokedRequests := newTestInventoryServer(t, sharedKey, inventory)
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	si := newSessionInventory(sharedKey, u)
+	session := func(id string) *sessions.State {
+		return &sessions.State{ID: id}
+	}
+
+	// the list is fetched in the background, not by the requests checking it
+	if si.IsRevoked(ctx, session("a")) {
+		t.Error("IsRevoked() before the list was fetched = true")
+	}
+	if n := atomic.LoadInt32(revokedRequests); n != 0 {
+		t.Errorf("revoked sessions fetched %d times, want 0", n)
+	}
+	si.update(ctx)
+	if !si.IsRevoked(ctx, session("a")) || si.IsRevoked(ctx, session("b")) {
+		t.Error("IsRevoked() wrong")
+	}
+	// service accounts are revoked in the configuration instead
+	if si.IsRevoked(ctx, &sessions.State{ID: "a", ServiceAccount: true}) {
+		t.Error("service account rejected")
+	}
+
+	records, err := si.list(ctx, "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 || records[0].ID != "b" {
+		t.Errorf("list() = %v, want session b", records)
+	}
+
+	if err := si.revoke(ctx, "c", "user"); err == nil {
+		t.Error("revoked another user's session")
+	}
+	if err := si.revoke(ctx, "b", "user"); err != nil {
+		t.Fatal(err)
+	}
+	// revocations made through the proxy apply before the list is refreshed
+	if !si.IsRevoked(ctx, session("b")) {
+		t.Error("revoked session not rejected")
+	}
+	if n := atomic.LoadInt32(revokedRequests); n != 1 {
+		t.Errorf("revoked sessions fetched %d times, want 1", n)
+	}
+
+	// the last known list is used while the authenticate service is down
+	srv.Close()
+	si.update(ctx)
+	if !si.IsRevoked(ctx, session("a")) || si.IsRevoked(ctx, session("d")) {
+		t.Error("IsRevoked() with the last known list wrong")
+	}
+}
+
+func TestSessionInventory_run(t *testing.T) {
+	t.Parallel()
+	sharedKey := testOptions(t).SharedKey
+	inventory := newTestInventory(t)
+	inventory.Revoke(context.Background(), "a", "")
+	old, oldRequests := newTestInventoryServer(t, sharedKey, inventory)
+	defer old.Close()
+	srv, revokedRequests := newTestInventoryServer(t, sharedKey, inventory)
+	defer srv.Close()
+	u, _ := url.Parse(old.URL)
+	si := newSessionInventory(sharedKey, u)
+	// the list is fetched from the authenticate service's current url
+	u, _ = url.Parse(srv.URL)
+	si.setAuthenticateURL(u)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		si.run(ctx)
+		close(done)
+	}()
+	deadline := time.Now().Add(time.Second)
+	for !si.IsRevoked(ctx, &sessions.State{ID: "a"}) {
+		if time.Now().After(deadline) {
+			t.Fatal("revoked sessions not fetched")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("run() did not stop")
+	}
+	if n := atomic.LoadInt32(oldRequests); n != 0 {
+		t.Errorf("old authenticate service fetched from %d times", n)
+	}
+	if n := atomic.LoadInt32(revokedRequests); n != 1 {
+		t.Errorf("revoked sessions fetched %d times, want 1", n)
+	}
+}
+
+func TestProxy_RevokeSession(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		id      string
+		user    string
+		isAdmin bool
+
+		wantStatus   int
+		wantLocation string
+		wantRevoked  string
+	}{
+		{"own session", "b", "", false, http.StatusFound, "/.pomerium/", "b"},
+		{"other user's session", "c", "other", false, http.StatusBadRequest, "", ""},
+		{"admin revokes other user's session", "c", "other", true, http.StatusFound, "/.pomerium/?user=other", "c"},
+		{"unknown session", "d", "", false, http.StatusBadRequest, "", ""},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := testOptions(t)
+			inventory := newTestInventory(t)
+			srv, _ := newTestInventoryServer(t, opts.SharedKey, inventory)
+			defer srv.Close()
+			p, err := New(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			u, _ := url.Parse(srv.URL)
+			p.inventory = newSessionInventory(opts.SharedKey, u)
+			p.AuthorizeClient = clients.MockAuthorize{IsAdminResponse: tt.isAdmin}
+
+			form := url.Values{"id": {tt.id}, "user": {tt.user}}
+			r := httptest.NewRequest(http.MethodPost, "https://corp.example.example/.pomerium/sessions/revoke", strings.NewReader(form.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			r.Header.Set("Accept", "application/json")
+			r = r.WithContext(sessions.NewContext(r.Context(), &sessions.State{ID: "a", Subject: "user"}, nil))
+			w := httptest.NewRecorder()
+			httputil.HandlerFunc(p.RevokeSession).ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %v, want %v\n%v", w.Code, tt.wantStatus, w.Body.String())
+			}
+			if tt.wantLocation != "" {
+				if got := w.Header().Get("Location"); got != "https://corp.example.example"+tt.wantLocation {
+					t.Errorf("Location = %q, want %q", got, tt.wantLocation)
+				}
+			}
+			for _, id := range []string{"a", "b", "c"} {
+				if got := inventory.IsRevoked(r.Context(), &sessions.State{ID: id}); got != (id == tt.wantRevoked) {
+					t.Errorf("session %s revoked = %v", id, got)
+				}
+			}
+		})
+	}
+}
+
+func TestProxy_UserDashboard_sessions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		user    string
+		isAdmin bool
+
+		want    []string
+		notWant []string
+	}{
+		{"own sessions", "", false, []string{"browser a", "browser b"}, []string{"browser c", "Look Up"}},
+		{"other user's sessions ignored", "other", false, []string{"browser a", "browser b"}, []string{"browser c"}},
+		{"admin looks up other user's sessions", "other", true, []string{"browser c", "Look Up"}, []string{"browser a", "browser b"}},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			opts := testOptions(t)
+			srv, _ := newTestInventoryServer(t, opts.SharedKey, newTestInventory(t))
+			defer srv.Close()
+			p, err := New(opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			u, _ := url.Parse(srv.URL)
+			p.inventory = newSessionInventory(opts.SharedKey, u)
+			p.AuthorizeClient = clients.MockAuthorize{IsAdminResponse: tt.isAdmin}
+
+			r := httptest.NewRequest(http.MethodGet, "https://corp.example.example/.pomerium/?user="+tt.user, nil)
+			r = r.WithContext(sessions.NewContext(r.Context(), &sessions.State{ID: "a", Subject: "user"}, nil))
+			w := httptest.NewRecorder()
+			httputil.HandlerFunc(p.UserDashboard).ServeHTTP(w, r)
+			body := w.Body.String()
+			for _, s := range tt.want {
+				if !strings.Contains(body, s) {
+					t.Errorf("dashboard missing %q", s)
+				}
+			}
+			for _, s := range tt.notWant {
+				if strings.Contains(body, s) {
+					t.Errorf("dashboard unexpectedly contains %q", s)
+				}
+			}
+		})
+	}
+}
+
+func TestProxy_serviceAccounts(t *testing.T) {
+	t.Parallel()
+	opts := testOptions(t)
+	srv, _ := newTestInventoryServer(t, opts.SharedKey, newTestInventory(t))
+	defer srv.Close()
+	opts.AuthenticateURL, _ = url.Parse(srv.URL)
+	p, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, _ := jws.NewHS256Signer([]byte(opts.SharedKey), "")
+	token, err := signer.Marshal(sessions.NewServiceAccount("ci", "ci", "ci@corp.example", []string{"deployers"}, nil, time.Now().Add(time.Hour)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	load := func() (*sessions.State, error) {
+		t.Helper()
+		var s *sessions.State
+		var err error
+		h := sessions.RetrieveSession(p.sessionLoaders...)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			s, err = sessions.FromContext(r.Context())
+		}))
+		r := httptest.NewRequest(http.MethodGet, "https://httpbin.corp.example/", nil)
+		r.Header.Set("Authorization", "Pomerium "+string(token))
+		h.ServeHTTP(httptest.NewRecorder(), r)
+		return s, err
+	}
+	s, err := load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !s.ServiceAccount || s.Email != "ci@corp.example" {
+		t.Errorf("loaded session = %+v", s)
+	}
+
+	// revoked service accounts are rejected once the options are updated
+	opts.RevokedServiceAccounts = []string{"ci"}
+	if err := p.UpdateOptions(opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := load(); !errors.Is(err, sessions.ErrRevoked) {
+		t.Errorf("revoked service account error = %v, want %v", err, sessions.ErrRevoked)
+	}
+}
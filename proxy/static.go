@@ -0,0 +1,39 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/httputil"
+)
+
+// redirectHandler replies to requests with a route's redirect.
+func redirectHandler(redirect *config.PolicyRedirect) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u := *redirect.Destination
+		if redirect.PreservePath {
+			u.Path = joinPath(u.Path, r.URL.Path)
+			u.RawPath = ""
+			switch {
+			case u.RawQuery == "":
+				u.RawQuery = r.URL.RawQuery
+			case r.URL.RawQuery != "":
+				u.RawQuery += "&" + r.URL.RawQuery
+			}
+		}
+		httputil.Redirect(w, r, u.String(), redirect.Status)
+	})
+}
+
+// staticResponseHandler replies to requests with a route's static response.
+func staticResponseHandler(response *config.PolicyResponse) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for key, val := range response.Headers {
+			w.Header().Set(key, val)
+		}
+		w.Header().Set(httputil.HeaderPomeriumResponse, "true")
+		w.WriteHeader(response.Status)
+		io.WriteString(w, response.Body)
+	})
+}
@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pomerium/pomerium/config"
+)
+
+func Test_redirectHandler(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name         string
+		redirect     config.PolicyRedirect
+		url          string
+		wantStatus   int
+		wantLocation string
+	}{
+		{"default status", config.PolicyRedirect{To: "https://new.example"}, "https://old.example/a/b?c=d", http.StatusFound, "https://new.example"},
+		{"permanent", config.PolicyRedirect{To: "https://new.example/path", Status: http.StatusMovedPermanently}, "https://old.example/a", http.StatusMovedPermanently, "https://new.example/path"},
+		{"preserve path", config.PolicyRedirect{To: "https://new.example", PreservePath: true}, "https://old.example/a/b?c=d", http.StatusFound, "https://new.example/a/b?c=d"},
+		{"preserve path with base", config.PolicyRedirect{To: "https://new.example/base/?x=y", PreservePath: true}, "https://old.example/a/b?c=d", http.StatusFound, "https://new.example/base/a/b?x=y&c=d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.redirect.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			r := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			w := httptest.NewRecorder()
+			redirectHandler(&tt.redirect).ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("redirectHandler() status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if diff := cmp.Diff(tt.wantLocation, w.Header().Get("Location")); diff != "" {
+				t.Errorf("redirectHandler() location :\n %s", diff)
+			}
+		})
+	}
+}
+
+func Test_staticResponseHandler(t *testing.T) {
+	t.Parallel()
+	response := config.PolicyResponse{
+		Status:  http.StatusServiceUnavailable,
+		Body:    "<h1>down for maintenance</h1>",
+		Headers: map[string]string{"Retry-After": "3600"},
+	}
+	if err := response.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	r := httptest.NewRequest(http.MethodGet, "https://httpbin.example/", nil)
+	w := httptest.NewRecorder()
+	staticResponseHandler(&response).ServeHTTP(w, r)
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("staticResponseHandler() status = %d, want %d", w.Code, http.StatusServiceUnavailable)
+	}
+	if diff := cmp.Diff(response.Body, w.Body.String()); diff != "" {
+		t.Errorf("staticResponseHandler() body :\n %s", diff)
+	}
+	if diff := cmp.Diff("3600", w.Header().Get("Retry-After")); diff != "" {
+		t.Errorf("staticResponseHandler() headers :\n %s", diff)
+	}
+}
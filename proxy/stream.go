@@ -0,0 +1,254 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/internal/urlutil"
+)
+
+// maxStreamCheckInterval bounds how often a long-lived connection's limits
+// are checked.
+const maxStreamCheckInterval = time.Second
+
+// superviseStreams returns middleware that closes long-lived connections,
+// such as websockets, TCP tunnels and gRPC streams, once they are idle for
+// too long, have been open for too long, or are no longer authorized.
+//
+// These connections are not bound by the route's timeout, so are otherwise
+// open for as long as the client, or the upstream, wants.
+func superviseStreams(policy *config.Policy, reauthorize func(*http.Request) error) func(next http.Handler) http.Handler {
+	reauthorizeInterval := policy.ReauthorizeInterval
+	if reauthorizeInterval == 0 {
+		reauthorizeInterval = config.DefaultReauthorizeInterval
+	}
+	limits := []time.Duration{policy.IdleTimeout, policy.MaxConnectionDuration}
+	if reauthorize != nil {
+		limits = append(limits, reauthorizeInterval)
+	}
+	interval := maxStreamCheckInterval
+	for _, limit := range limits {
+		if limit > 0 && limit < interval {
+			interval = limit
+		}
+	}
+	return func(next http.Handler) http.Handler {
+		// connections to public routes without limits are not supervised
+		if reauthorize == nil && policy.IdleTimeout == 0 && policy.MaxConnectionDuration == 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+			s := &streamSupervisor{
+				idleTimeout:         policy.IdleTimeout,
+				maxDuration:         policy.MaxConnectionDuration,
+				reauthorizeInterval: reauthorizeInterval,
+				reauthorize:         reauthorize,
+				cancel:              cancel,
+				start:               time.Now(),
+			}
+			s.touch()
+			r = r.WithContext(ctx)
+			if r.Body != nil && r.Body != http.NoBody {
+				r.Body = &streamReadCloser{ReadCloser: r.Body, s: s}
+			}
+			done := make(chan struct{})
+			defer close(done)
+			go s.watch(r, interval, done)
+			next.ServeHTTP(&streamResponseWriter{ResponseWriter: w, s: s}, r)
+		})
+	}
+}
+
+// longLived applies a middleware only to a route's requests that are not
+// bound by its timeout: all requests to websocket and TCP routes, and gRPC
+// calls to any route.
+func longLived(policy *config.Policy, mw func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	if policy.AllowWebsockets || policy.IsTCP() {
+		return mw
+	}
+	return func(next http.Handler) http.Handler {
+		wrapped := mw(next)
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isGRPCRequest(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			wrapped.ServeHTTP(w, r)
+		})
+	}
+}
+
+type streamSupervisor struct {
+	idleTimeout         time.Duration
+	maxDuration         time.Duration
+	reauthorizeInterval time.Duration
+	reauthorize         func(*http.Request) error
+
+	start time.Time
+	// lastActive is the time, in unix nanoseconds, data was last sent
+	lastActive int64
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	conn   net.Conn
+}
+
+func (s *streamSupervisor) touch() {
+	atomic.StoreInt64(&s.lastActive, time.Now().UnixNano())
+}
+
+// watch checks the connection's limits until the request is done, and
+// closes the connection once any is exceeded.
+func (s *streamSupervisor) watch(r *http.Request, interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	lastAuthorized := s.start
+	for {
+		select {
+		case <-done:
+			return
+		case now := <-ticker.C:
+			var err error
+			switch {
+			case s.maxDuration > 0 && now.Sub(s.start) >= s.maxDuration:
+				err = errors.New("max connection duration exceeded")
+			case s.idleTimeout > 0 && now.Sub(time.Unix(0, atomic.LoadInt64(&s.lastActive))) >= s.idleTimeout:
+				err = errors.New("idle timeout exceeded")
+			case s.reauthorize != nil && now.Sub(lastAuthorized) >= s.reauthorizeInterval:
+				lastAuthorized = now
+				err = s.reauthorize(r)
+			}
+			if err != nil {
+				log.FromRequest(r).Info().Err(err).Msg("proxy: closing long-lived connection")
+				s.close()
+				return
+			}
+		}
+	}
+}
+
+// close cancels the request and closes its hijacked connection, if any.
+func (s *streamSupervisor) close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cancel()
+	if s.conn != nil {
+		s.conn.Close()
+	}
+}
+
+// streamResponseWriter records activity on the response, and on the
+// connection if it is hijacked.
+type streamResponseWriter struct {
+	http.ResponseWriter
+	s *streamSupervisor
+}
+
+func (w *streamResponseWriter) Write(p []byte) (int, error) {
+	w.s.touch()
+	return w.ResponseWriter.Write(p)
+}
+
+// Flush implements http.Flusher.
+func (w *streamResponseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// Hijack implements http.Hijacker.
+func (w *streamResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hj, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("proxy: response writer does not support hijacking")
+	}
+	conn, brw, err := hj.Hijack()
+	if err != nil {
+		return nil, nil, err
+	}
+	sc := &streamConn{Conn: conn, s: w.s}
+	// the buffered reader may hold bytes the client sent after its request
+	buffered, _ := brw.Reader.Peek(brw.Reader.Buffered())
+	rd := io.MultiReader(bytes.NewReader(append([]byte(nil), buffered...)), sc)
+
+	w.s.mu.Lock()
+	defer w.s.mu.Unlock()
+	w.s.conn = sc
+	return sc, bufio.NewReadWriter(bufio.NewReader(rd), bufio.NewWriter(sc)), nil
+}
+
+// Unwrap returns the underlying response writer.
+func (w *streamResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// streamConn records activity on a hijacked connection.
+type streamConn struct {
+	net.Conn
+	s *streamSupervisor
+}
+
+func (c *streamConn) Read(p []byte) (int, error) {
+	n, err := c.Conn.Read(p)
+	if n > 0 {
+		c.s.touch()
+	}
+	return n, err
+}
+
+func (c *streamConn) Write(p []byte) (int, error) {
+	c.s.touch()
+	return c.Conn.Write(p)
+}
+
+// CloseWrite half-closes the connection, if it is supported, so that TCP
+// tunnels can tell the client the upstream is done sending.
+func (c *streamConn) CloseWrite() error {
+	if cw, ok := c.Conn.(interface{ CloseWrite() error }); ok {
+		return cw.CloseWrite()
+	}
+	return c.Conn.Close()
+}
+
+// streamReadCloser records activity on a request body.
+type streamReadCloser struct {
+	io.ReadCloser
+	s *streamSupervisor
+}
+
+func (r *streamReadCloser) Read(p []byte) (int, error) {
+	n, err := r.ReadCloser.Read(p)
+	if n > 0 {
+		r.s.touch()
+	}
+	return n, err
+}
+
+// reauthorize checks that a long-lived connection's session is still valid,
+// has not been revoked, and that its user is still authorized for the route.
+func (p *Proxy) reauthorize(r *http.Request) error {
+	s, err := sessions.FromContext(r.Context())
+	if err != nil {
+		return err
+	}
+	if err := s.Verify(urlutil.StripPort(r.Host)); err != nil {
+		return err
+	}
+	if p.inventory.IsRevoked(r.Context(), s) || (s.ServiceAccount && p.revokedServiceAccounts.IsRevoked(r.Context(), s)) {
+		return sessions.ErrRevoked
+	}
+	return p.authorize(p.routeID(r.Host, r.URL.Path), r)
+}
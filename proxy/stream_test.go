@@ -0,0 +1,187 @@
+package proxy
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pomerium/pomerium/config"
+	"github.com/pomerium/pomerium/internal/sessions"
+	"github.com/pomerium/pomerium/proxy/clients"
+)
+
+func TestSuperviseStreams(t *testing.T) {
+	t.Parallel()
+	authorized := func(*http.Request) error { return nil }
+	unauthorized := func(*http.Request) error { return errors.New("not authorized") }
+
+	tests := []struct {
+		name        string
+		policy      config.Policy
+		reauthorize func(*http.Request) error
+		active      bool
+		wantClosed  bool
+	}{
+		{"idle", config.Policy{IdleTimeout: 20 * time.Millisecond}, nil, false, true},
+		{"active", config.Policy{IdleTimeout: 50 * time.Millisecond}, nil, true, false},
+		{"max duration", config.Policy{MaxConnectionDuration: 50 * time.Millisecond}, nil, true, true},
+		{"still authorized", config.Policy{ReauthorizeInterval: 10 * time.Millisecond}, authorized, false, false},
+		{"no longer authorized", config.Policy{ReauthorizeInterval: 10 * time.Millisecond}, unauthorized, false, true},
+		{"public", config.Policy{ReauthorizeInterval: 10 * time.Millisecond}, nil, false, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			closed := make(chan struct{})
+			h := superviseStreams(&tt.policy, tt.reauthorize)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ticker := time.NewTicker(5 * time.Millisecond)
+				defer ticker.Stop()
+				deadline := time.After(200 * time.Millisecond)
+				for {
+					select {
+					case <-r.Context().Done():
+						close(closed)
+						return
+					case <-deadline:
+						return
+					case <-ticker.C:
+						if tt.active {
+							io.WriteString(w, "event\n")
+						}
+					}
+				}
+			}))
+			h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+			select {
+			case <-closed:
+				if !tt.wantClosed {
+					t.Error("connection was closed")
+				}
+			default:
+				if tt.wantClosed {
+					t.Error("connection was not closed")
+				}
+			}
+		})
+	}
+}
+
+func TestSuperviseStreams_unlimited(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		policy         config.Policy
+		reauthorize    func(*http.Request) error
+		wantSupervised bool
+	}{
+		{"public", config.Policy{}, nil, false},
+		{"idle timeout", config.Policy{IdleTimeout: time.Minute}, nil, true},
+		{"max duration", config.Policy{MaxConnectionDuration: time.Minute}, nil, true},
+		{"reauthorized", config.Policy{}, func(*http.Request) error { return nil }, true},
+	}
+	for _, tt := range tests {
+		var supervised bool
+		h := superviseStreams(&tt.policy, tt.reauthorize)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, supervised = w.(*streamResponseWriter)
+		}))
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		if supervised != tt.wantSupervised {
+			t.Errorf("%s: supervised = %v, want %v", tt.name, supervised, tt.wantSupervised)
+		}
+	}
+}
+
+func TestSuperviseStreams_Hijacked(t *testing.T) {
+	t.Parallel()
+	policy := &config.Policy{IdleTimeout: 20 * time.Millisecond}
+	srv := httptest.NewServer(superviseStreams(policy, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, brw, err := w.(http.Hijacker).Hijack()
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		io.WriteString(conn, "HTTP/1.1 101 Switching Protocols\r\n\r\n")
+		// echo until the connection is closed
+		io.Copy(conn, brw)
+	})))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	io.WriteString(conn, "GET / HTTP/1.1\r\nHost: example.com\r\nUpgrade: websocket\r\nConnection: upgrade\r\n\r\n")
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	if _, err := ioutil.ReadAll(conn); err != nil {
+		t.Errorf("idle connection was not closed: %v", err)
+	}
+}
+
+func Test_longLived(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name        string
+		policy      config.Policy
+		contentType string
+		want        bool
+	}{
+		{"http", config.Policy{}, "text/html", false},
+		{"grpc", config.Policy{}, "application/grpc", true},
+		{"websockets", config.Policy{AllowWebsockets: true}, "text/html", true},
+		{"tcp", config.Policy{From: "tcp+https://redis.corp.example:6379"}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got bool
+			mw := func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					got = true
+					next.ServeHTTP(w, r)
+				})
+			}
+			h := longLived(&tt.policy, mw)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Content-Type", tt.contentType)
+			h.ServeHTTP(httptest.NewRecorder(), r)
+			if got != tt.want {
+				t.Errorf("longLived() applied = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProxy_reauthorize(t *testing.T) {
+	t.Parallel()
+	p, err := New(testOptions(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	p.AuthorizeClient = clients.MockAuthorize{AuthorizeResponse: true}
+	p.revokedServiceAccounts.Set([]string{"ci"})
+	tests := []struct {
+		name    string
+		session *sessions.State
+		wantErr error
+	}{
+		{"authorized", &sessions.State{ID: "a"}, nil},
+		{"revoked", &sessions.State{ID: "b"}, sessions.ErrRevoked},
+		{"revoked service account", &sessions.State{ID: "ci", ServiceAccount: true}, sessions.ErrRevoked},
+	}
+	p.inventory.revoked["b"] = struct{}{}
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "https://corp.example.example/", nil)
+		r = r.WithContext(sessions.NewContext(r.Context(), tt.session, nil))
+		if err := p.reauthorize(r); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: reauthorize() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
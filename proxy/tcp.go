@@ -0,0 +1,48 @@
+package proxy // import "github.com/pomerium/pomerium/proxy"
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pomerium/pomerium/internal/httputil"
+	"github.com/pomerium/pomerium/internal/log"
+	"github.com/pomerium/pomerium/internal/tcputil"
+)
+
+// tcpTunnel returns a handler that accepts HTTP CONNECT requests, and splices
+// the hijacked client connection to a TCP upstream. The upstream connection
+// must be established within the timeout.
+func tcpTunnel(destination string, timeout time.Duration) http.Handler {
+	return httputil.HandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		hj, ok := w.(http.Hijacker)
+		if !ok {
+			return httputil.NewError(http.StatusInternalServerError, errors.New("proxy: connection does not support tunneling"))
+		}
+		dialer := net.Dialer{Timeout: timeout}
+		upstream, err := dialer.DialContext(r.Context(), "tcp", destination)
+		if err != nil {
+			return httputil.NewError(http.StatusBadGateway, err)
+		}
+		defer upstream.Close()
+
+		conn, brw, err := hj.Hijack()
+		if err != nil {
+			return httputil.NewError(http.StatusInternalServerError, err)
+		}
+		defer conn.Close()
+		// clear any deadlines set by the server; tunnels are long lived
+		if err := conn.SetDeadline(time.Time{}); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(conn, "HTTP/1.1 200 Connection established\r\n\r\n"); err != nil {
+			return err
+		}
+		log.FromRequest(r).Debug().Str("destination", destination).Msg("proxy: tcp tunnel established")
+		// the buffered reader may hold bytes the client sent after its request
+		tcputil.Splice(conn, brw.Reader, upstream, upstream)
+		return nil
+	})
+}
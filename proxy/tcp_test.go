@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTCPTunnel(t *testing.T) {
+	t.Parallel()
+	// an upstream that echoes everything it is sent
+	upstream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer upstream.Close()
+	go func() {
+		for {
+			conn, err := upstream.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				io.Copy(conn, conn)
+			}()
+		}
+	}()
+
+	srv := httptest.NewServer(tcpTunnel(upstream.Addr().String(), time.Second))
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	// the client's first bytes are sent along with the CONNECT request
+	if _, err := io.WriteString(conn, "CONNECT redis.example:6379 HTTP/1.1\r\nHost: redis.example:6379\r\n\r\nhello"); err != nil {
+		t.Fatal(err)
+	}
+	br := bufio.NewReader(conn)
+	res, err := http.ReadResponse(br, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("tcpTunnel() status = %d, want %d", res.StatusCode, http.StatusOK)
+	}
+	if _, err := io.WriteString(conn, " world"); err != nil {
+		t.Fatal(err)
+	}
+	conn.(*net.TCPConn).CloseWrite()
+	got, err := ioutil.ReadAll(br)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "hello world" {
+		t.Errorf("tcpTunnel() = %q, want %q", got, "hello world")
+	}
+}
+
+func TestTCPTunnel_BadUpstream(t *testing.T) {
+	t.Parallel()
+	upstream, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	upstream.Close()
+
+	srv := httptest.NewServer(tcpTunnel(upstream.Addr().String(), time.Second))
+	defer srv.Close()
+	conn, err := net.Dial("tcp", srv.Listener.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	if _, err := io.WriteString(conn, "CONNECT redis.example:6379 HTTP/1.1\r\nHost: redis.example:6379\r\n\r\n"); err != nil {
+		t.Fatal(err)
+	}
+	res, err := http.ReadResponse(bufio.NewReader(conn), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.StatusCode != http.StatusBadGateway {
+		t.Errorf("tcpTunnel() status = %d, want %d", res.StatusCode, http.StatusBadGateway)
+	}
+}
+
+func TestTCPTunnel_NotHijackable(t *testing.T) {
+	t.Parallel()
+	r := httptest.NewRequest(http.MethodConnect, "/", nil)
+	w := httptest.NewRecorder()
+	tcpTunnel("127.0.0.1:0", time.Second).ServeHTTP(w, r)
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("tcpTunnel() status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+}